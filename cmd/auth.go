@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// Cookies the OpenLabs API sets on a successful login. The token cookie holds
+// the session JWT and enc_key holds the key that unlocks the user's secrets.
+const (
+	authTokenCookie = "token"
+	encKeyCookie    = "enc_key"
+)
+
+// authSession is what a successful login hands back to the CLI.
+type authSession struct {
+	Token        string
+	EncKey       string
+	RefreshToken string
+}
+
+// authenticate performs the OpenLabs login handshake. The API answers a POST
+// of the credentials to /api/v1/auth/login with {"success": true} and sets the
+// token and enc_key cookies; anything else is an error. Accounts with a second
+// factor get {"mfa_required": true} instead, and secondFactor supplies the
+// code; without it the login fails with errMFARequired.
+func authenticate(email, password string, secondFactor func() (string, error)) (authSession, error) {
+	client := NewClient()
+	client.AuthToken = "" // Log in from a clean session
+	client.EncKey = ""
+	client.APIKey = ""
+
+	resp, err := client.DoRequest("POST", "/api/v1/auth/login", UserCredentials{
+		Email:    email,
+		Password: password,
+	})
+	if err != nil {
+		return authSession{}, fmt.Errorf("login request failed: %w", err)
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var result struct {
+		Success     bool   `json:"success"`
+		MFARequired bool   `json:"mfa_required"`
+		MFAToken    string `json:"mfa_token"`
+	}
+	if err := ParseResponse(resp, &result); err != nil {
+		return authSession{}, fmt.Errorf("login failed: %s", err)
+	}
+	if result.MFARequired {
+		if secondFactor == nil {
+			return authSession{}, errMFARequired
+		}
+		code, err := secondFactor()
+		if err != nil {
+			return authSession{}, err
+		}
+		return completeMFAChallenge(result.MFAToken, code)
+	}
+	if !result.Success {
+		return authSession{}, fmt.Errorf("login failed: the API did not accept the credentials")
+	}
+
+	return sessionFromCookies(resp.Cookies())
+}
+
+// sessionFromCookies extracts the session from login response cookies.
+func sessionFromCookies(cookies []*http.Cookie) (authSession, error) {
+	var session authSession
+	for _, cookie := range cookies {
+		switch cookie.Name {
+		case authTokenCookie:
+			session.Token = cookie.Value
+		case encKeyCookie:
+			session.EncKey = cookie.Value
+		case refreshTokenCookie:
+			session.RefreshToken = cookie.Value
+		}
+	}
+
+	if session.Token == "" {
+		return authSession{}, fmt.Errorf("login succeeded but the API did not set the %q cookie; check that %s is an OpenLabs API", authTokenCookie, APIURL)
+	}
+	if session.EncKey == "" {
+		return authSession{}, fmt.Errorf("login succeeded but the API did not set the %q cookie needed to use your cloud secrets", encKeyCookie)
+	}
+	return session, nil
+}
+
+// warnAPIKeyEnv points out that an API key in the environment still takes
+// precedence over the session a login just saved.
+func warnAPIKeyEnv() {
+	if os.Getenv(apiKeyEnv) != "" {
+		fmt.Printf("\n⚠️  %s is set and takes precedence over this login; unset it to use the new session.\n", apiKeyEnv)
+	}
+}
+
+// Auth Commands.
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Inspect and manage authentication",
+	Long:  "This command lets you inspect your OpenLabs session and manage how the CLI authenticates.",
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+}
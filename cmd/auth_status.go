@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var authStatusCmd = &cobra.Command{
+	Use:     "status",
+	Aliases: []string{"whoami"},
+	Short:   "Show the current session",
+	Long: "This command shows which server the CLI talks to, who you are logged in as, when your session " +
+		"expires, and where credentials are stored. It exits non-zero when you are not authenticated.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if !showAuthStatus() {
+			os.Exit(1)
+		}
+	},
+}
+
+// Auth Status Implementation.
+
+// showAuthStatus prints the session diagnostics and reports whether the API
+// accepted the session.
+func showAuthStatus() bool {
+	client := NewClient()
+	config, _ := loadConfig()
+
+	credentials := "none"
+	if client.APIKey != "" && os.Getenv(apiKeyEnv) != "" {
+		credentials = apiKeyEnv
+	} else if path, err := getConfigPath(); err == nil && (config.AuthToken != "" || config.APIKey != "") {
+		credentials = path
+	} else if client.AuthToken != "" {
+		credentials = "--token flag"
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+	table.SetCenterSeparator("|")
+	table.SetColumnSeparator("|")
+	table.SetRowSeparator("-")
+
+	table.Append([]string{"Server", client.BaseURL})
+	table.Append([]string{"Credentials", credentials})
+
+	user, userErr := fetchCurrentUser()
+	if userErr == nil {
+		role := "user"
+		if user.Admin {
+			role = "admin"
+		}
+		table.Append([]string{"User", fmt.Sprintf("%s <%s> (%s)", user.Name, user.Email, role)})
+	} else {
+		table.Append([]string{"User", "❌ " + userErr.Error()})
+	}
+
+	if client.APIKey != "" {
+		table.Append([]string{"API key", maskAPIKey(client.APIKey)})
+	} else {
+		// The request above may have renewed the session, so read the token afterwards
+		token := AuthToken
+		if token == "" {
+			token = client.AuthToken
+		}
+		for _, row := range tokenStatusRows(token) {
+			table.Append(row)
+		}
+	}
+
+	encKey := "❌ Missing (log in again before deploying ranges)"
+	if client.EncKey != "" || EncKey != "" {
+		encKey = "✅ Present"
+	}
+	table.Append([]string{"Encryption key", encKey})
+
+	refresh := "No"
+	if config.RefreshToken != "" {
+		refresh = "Yes"
+	}
+	table.Append([]string{"Refresh token", refresh})
+
+	fmt.Println()
+	table.Render()
+
+	if userErr != nil {
+		fmt.Println("\n❌ Not authenticated")
+		return false
+	}
+	fmt.Println("\n✅ Authenticated")
+	return true
+}
+
+// tokenStatusRows describes a session token, decoding it when it is a JWT.
+func tokenStatusRows(token string) [][]string {
+	if token == "" {
+		return [][]string{{"Token", "❌ None"}}
+	}
+
+	claims, err := parseTokenClaims(token)
+	if err != nil {
+		return [][]string{{"Token", "Present (not a JWT; expiry unknown)"}}
+	}
+
+	rows := [][]string{{"Token", "JWT"}}
+	if issued, ok := claims.issued(); ok {
+		rows = append(rows, []string{"Issued", issued.Local().Format(time.RFC1123)})
+	}
+	if expires, ok := claims.expiry(); ok {
+		remaining := time.Until(expires)
+		status := fmt.Sprintf("%s (in %s)", expires.Local().Format(time.RFC1123), formatRemaining(remaining))
+		switch {
+		case remaining <= 0:
+			status = fmt.Sprintf("%s (❌ expired %s ago)", expires.Local().Format(time.RFC1123), formatRemaining(remaining))
+		case remaining <= sessionWarnWindow:
+			status += " ⚠️"
+		}
+		rows = append(rows, []string{"Expires", status})
+	} else {
+		rows = append(rows, []string{"Expires", "Never (no exp claim)"})
+	}
+	return rows
+}
+
+func init() {
+	authCmd.AddCommand(authStatusCmd)
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestAPI points the CLI at a fake API server for the length of a test.
+// The config is kept in a temporary home directory so tests never touch the
+// real one.
+func newTestAPI(t *testing.T, handler http.Handler) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(apiKeyEnv, "")
+
+	oldAPIURL, oldAuthToken, oldEncKey := APIURL, AuthToken, EncKey
+	APIURL, AuthToken, EncKey = server.URL, "", ""
+	t.Cleanup(func() {
+		APIURL, AuthToken, EncKey = oldAPIURL, oldAuthToken, oldEncKey
+	})
+
+	return server
+}
+
+func TestAuthenticate(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		cookies []*http.Cookie
+		// code answers a second factor challenge, and mfaBody is the reply
+		// to it; the cookies are then set on that reply instead
+		code    string
+		mfaBody string
+		want    authSession
+		wantErr string
+	}{
+		{
+			name:   "success",
+			status: http.StatusOK,
+			body:   `{"success": true}`,
+			cookies: []*http.Cookie{
+				{Name: authTokenCookie, Value: "token-value"},
+				{Name: encKeyCookie, Value: "key-value"},
+				{Name: refreshTokenCookie, Value: "refresh-value"},
+			},
+			want: authSession{Token: "token-value", EncKey: "key-value", RefreshToken: "refresh-value"},
+		},
+		{
+			name:    "missing token cookie",
+			status:  http.StatusOK,
+			body:    `{"success": true}`,
+			cookies: []*http.Cookie{{Name: encKeyCookie, Value: "key-value"}},
+			wantErr: `did not set the "token" cookie`,
+		},
+		{
+			name:    "missing enc_key cookie",
+			status:  http.StatusOK,
+			body:    `{"success": true}`,
+			cookies: []*http.Cookie{{Name: authTokenCookie, Value: "token-value"}},
+			wantErr: `did not set the "enc_key" cookie`,
+		},
+		{
+			name:   "not successful",
+			status: http.StatusOK,
+			body:   `{"success": false}`,
+			cookies: []*http.Cookie{
+				{Name: authTokenCookie, Value: "token-value"},
+				{Name: encKeyCookie, Value: "key-value"},
+			},
+			wantErr: "did not accept the credentials",
+		},
+		{
+			name:    "unauthorized",
+			status:  http.StatusUnauthorized,
+			body:    `{"detail": "Incorrect email or password"}`,
+			wantErr: "login failed: request failed with status: 401 Unauthorized - Incorrect email or password",
+		},
+		{
+			name:    "second factor required",
+			status:  http.StatusOK,
+			body:    `{"success": false, "mfa_required": true, "mfa_token": "challenge"}`,
+			wantErr: errMFARequired.Error(),
+		},
+		{
+			name:    "second factor",
+			status:  http.StatusOK,
+			body:    `{"success": false, "mfa_required": true, "mfa_token": "challenge"}`,
+			code:    "123456",
+			mfaBody: `{"success": true}`,
+			cookies: []*http.Cookie{
+				{Name: authTokenCookie, Value: "token-value"},
+				{Name: encKeyCookie, Value: "key-value"},
+			},
+			want: authSession{Token: "token-value", EncKey: "key-value"},
+		},
+		{
+			name:    "second factor rejected",
+			status:  http.StatusOK,
+			body:    `{"success": false, "mfa_required": true, "mfa_token": "challenge"}`,
+			code:    "000000",
+			mfaBody: `{"success": false}`,
+			wantErr: "did not accept the code",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var credentials UserCredentials
+			var challenge MFAChallenge
+			newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status, body := tt.status, tt.body
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/api/v1/auth/login":
+					if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+						t.Errorf("failed to decode login request: %s", err)
+					}
+				case r.Method == http.MethodPost && r.URL.Path == "/api/v1/auth/login/mfa" && tt.mfaBody != "":
+					if err := json.NewDecoder(r.Body).Decode(&challenge); err != nil {
+						t.Errorf("failed to decode MFA request: %s", err)
+					}
+					status, body = http.StatusOK, tt.mfaBody
+				default:
+					http.NotFound(w, r)
+					return
+				}
+				if tt.mfaBody == "" || r.URL.Path == "/api/v1/auth/login/mfa" {
+					for _, c := range tt.cookies {
+						http.SetCookie(w, c)
+					}
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte(body))
+			}))
+
+			var secondFactor func() (string, error)
+			if tt.code != "" {
+				secondFactor = func() (string, error) { return tt.code, nil }
+			}
+			got, err := authenticate("user@example.com", "correct horse", secondFactor)
+
+			if credentials.Email != "user@example.com" || credentials.Password != "correct horse" {
+				t.Errorf("login sent credentials %+v", credentials)
+			}
+			if tt.code != "" && (challenge.MFAToken != "challenge" || challenge.Code != tt.code) {
+				t.Errorf("MFA request sent %+v, want token %q and code %q", challenge, "challenge", tt.code)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("authenticate() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("authenticate() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("authenticate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticateIgnoresSavedSession(t *testing.T) {
+	newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.Cookies()) > 0 || r.Header.Get("Authorization") != "" || r.Header.Get(apiKeyHeader) != "" {
+			t.Errorf("login request carried credentials from the saved session")
+		}
+		http.SetCookie(w, &http.Cookie{Name: authTokenCookie, Value: "new-token"})
+		http.SetCookie(w, &http.Cookie{Name: encKeyCookie, Value: "new-key"})
+		_, _ = w.Write([]byte(`{"success": true}`))
+	}))
+	AuthToken, EncKey = "old-token", "old-key"
+
+	if _, err := authenticate("user@example.com", "correct horse", nil); err != nil {
+		t.Fatalf("authenticate() error = %v", err)
+	}
+}
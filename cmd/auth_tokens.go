@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// apiKeyHeader carries an API key in place of the session cookies.
+const apiKeyHeader = "X-API-Key"
+
+// apiKeyEnv selects API key authentication, which takes precedence over a
+// stored session.
+const apiKeyEnv = "OPENLABS_API_KEY"
+
+var errAPIKeyRejected = errors.New("the API key was rejected; it may have expired or been revoked")
+
+// API key model structures.
+type APIKey struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+type APIKeyCreate struct {
+	Name      string     `json:"name"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// APIKeyCreated is returned once when a key is created; the API never shows
+// the key again.
+type APIKeyCreated struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// API Key Commands.
+var authTokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage API keys for automation",
+	Long: "This command manages long-lived API keys. Set " + apiKeyEnv + " to a key to authenticate with it " +
+		"instead of a login session.",
+}
+
+var createAuthTokenCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an API key",
+	Long:  "This command creates an API key. The key is shown only once, so store it somewhere safe.",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+		expiresFlag, _ := cmd.Flags().GetString("expires")
+		save, _ := cmd.Flags().GetBool("save")
+
+		if name == "" {
+			fmt.Println("Error: --name is required")
+			return
+		}
+
+		var expiresAt *time.Time
+		if expiresFlag != "never" {
+			lifetime, err := parseLifetime(expiresFlag)
+			if err != nil {
+				fmt.Printf("Error: invalid --expires: %s\n", err)
+				return
+			}
+			t := time.Now().Add(lifetime).UTC()
+			expiresAt = &t
+		}
+
+		err := createAPIKey(name, expiresAt, save)
+		if err != nil {
+			printError(err)
+		}
+	},
+}
+
+var listAuthTokensCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API keys",
+	Long:  "This command lists your API keys. The keys themselves cannot be shown again.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := listAPIKeys()
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+var revokeAuthTokenCmd = &cobra.Command{
+	Use:   "revoke [key-id|name]",
+	Short: "Revoke an API key",
+	Long:  "This command revokes an API key so it can no longer be used.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := resolveID("API key", args[0], apiKeyResources)
+		if err != nil {
+			fmt.Printf("Error: %s\n", err)
+			return
+		}
+		if !confirmDestructive(fmt.Sprintf("revoke API key %d", id), describeAPIKey(id)) {
+			return
+		}
+		err = revokeAPIKey(id)
+		if err != nil {
+			printError(err)
+		}
+	},
+}
+
+// API Key Implementation.
+func createAPIKey(name string, expiresAt *time.Time, save bool) error {
+	client := NewClient()
+	resp, err := client.DoRequest("POST", "/api/v1/users/me/api-keys", APIKeyCreate{
+		Name:      name,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var created APIKeyCreated
+	if err := ParseResponse(resp, &created); err != nil {
+		return err
+	}
+	if created.Key == "" {
+		return fmt.Errorf("the API created key %d but did not return it; revoke it and try again", created.ID)
+	}
+
+	fmt.Printf("\n✅ API key %s created (ID %d, expires %s)\n", created.Name, created.ID, formatKeyExpiry(created.ExpiresAt))
+	fmt.Printf("\n  %s\n\n", created.Key)
+	fmt.Println("This key will not be shown again. Use it by setting " + apiKeyEnv + ".")
+
+	if save {
+		config, _ := loadConfig()
+		config.APIKey = created.Key
+		if err := saveConfig(config); err != nil {
+			return fmt.Errorf("key created but not saved: %s", err)
+		}
+		fmt.Println("The key has been saved and will be used instead of your login session.")
+	}
+	return nil
+}
+
+func listAPIKeys() error {
+	keys, err := fetchAPIKeys()
+	if err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		fmt.Println("No API keys found")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Name", "Prefix", "Created", "Expires", "Last Used"})
+	for _, k := range keys {
+		lastUsed := "Never"
+		if k.LastUsedAt != nil {
+			lastUsed = k.LastUsedAt.Local().Format("2006-01-02 15:04")
+		}
+		table.Append([]string{
+			strconv.Itoa(k.ID),
+			k.Name,
+			k.Prefix,
+			k.CreatedAt.Local().Format("2006-01-02 15:04"),
+			formatKeyExpiry(k.ExpiresAt),
+			lastUsed,
+		})
+	}
+	table.Render()
+	return nil
+}
+
+func fetchAPIKeys() ([]APIKey, error) {
+	client := NewClient()
+	resp, err := client.DoRequest("GET", "/api/v1/users/me/api-keys", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var keys []APIKey
+	if err := ParseResponse(resp, &keys); err != nil {
+		return nil, err
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	return keys, nil
+}
+
+func revokeAPIKey(id int) error {
+	client := NewClient()
+	resp, err := client.DoRequest("DELETE", fmt.Sprintf("/api/v1/users/me/api-keys/%d", id), nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var result bool
+	if err := ParseResponse(resp, &result); err != nil {
+		return err
+	}
+
+	if result {
+		fmt.Println("API key revoked successfully")
+	} else {
+		fmt.Println("Failed to revoke API key")
+	}
+	return nil
+}
+
+func apiKeyResources() ([]namedResource, error) {
+	keys, err := fetchAPIKeys()
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]namedResource, 0, len(keys))
+	for _, k := range keys {
+		resources = append(resources, namedResource{ID: k.ID, Name: k.Name, Alias: k.Prefix})
+	}
+	return resources, nil
+}
+
+func formatKeyExpiry(expiresAt *time.Time) string {
+	if expiresAt == nil {
+		return "never"
+	}
+	if time.Until(*expiresAt) <= 0 {
+		return "expired"
+	}
+	return expiresAt.Local().Format("2006-01-02 15:04")
+}
+
+// maskAPIKey shows enough of a key to recognize it.
+func maskAPIKey(key string) string {
+	if len(key) <= 8 {
+		return strings.Repeat("*", len(key))
+	}
+	return key[:4] + strings.Repeat("*", 8) + key[len(key)-4:]
+}
+
+func init() {
+	createAuthTokenCmd.Flags().String("name", "", "Name of the API key (e.g., ci)")
+	createAuthTokenCmd.Flags().String("expires", "90d", "How long the key is valid (e.g., 30d, 12h), or 'never'")
+	createAuthTokenCmd.Flags().Bool("save", false, "Use the new key for this CLI instead of your login session")
+
+	_ = createAuthTokenCmd.RegisterFlagCompletionFunc("expires", completeValues("30d", "90d", "365d", "never"))
+	revokeAuthTokenCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeResources("api-key", apiKeyResources, toComplete)
+	}
+
+	authTokenCmd.AddCommand(createAuthTokenCmd)
+	authTokenCmd.AddCommand(listAuthTokensCmd)
+	authTokenCmd.AddCommand(revokeAuthTokenCmd)
+
+	authCmd.AddCommand(authTokenCmd)
+}
@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// defaultOAuthClientID is the client the OpenLabs CLI is registered as with
+// the identity provider.
+const defaultOAuthClientID = "openlabs-cli"
+
+// webLoginTimeout is how long the CLI waits for the browser to come back.
+const webLoginTimeout = 5 * time.Minute
+
+// oauthProvider holds the endpoints from the issuer's OpenID discovery document.
+type oauthProvider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+}
+
+// oauthToken is a token endpoint response. EncKey is an OpenLabs extension
+// carrying the key that unlocks the user's secrets.
+type oauthToken struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	EncKey       string `json:"enc_key"`
+	Error        string `json:"error"`
+	ErrorDesc    string `json:"error_description"`
+}
+
+// oauthCallback is what the identity provider sends back to the listener.
+type oauthCallback struct {
+	Code string
+	Err  error
+}
+
+// webLogin logs in through the browser using the OAuth2 authorization code
+// flow with PKCE. The identity provider redirects back to a listener on
+// 127.0.0.1, and the tokens it issues are saved like a password login.
+func webLogin(issuer, clientID string, openBrowser bool) error {
+	if DryRun {
+		return fmt.Errorf("web login cannot be used with --dry-run")
+	}
+	if issuer == "" {
+		issuer = APIURL
+	}
+
+	fmt.Println("\n🔒 Authenticating in your browser...")
+
+	provider, err := discoverOAuthProvider(issuer)
+	if err != nil {
+		return err
+	}
+
+	verifier, err := randomURLString(32)
+	if err != nil {
+		return err
+	}
+	state, err := randomURLString(16)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("failed to start the login listener: %s", err)
+	}
+	redirectURI := fmt.Sprintf("http://%s/callback", listener.Addr().String())
+
+	callbacks := make(chan oauthCallback, 1)
+	server := &http.Server{
+		Handler:           oauthCallbackHandler(state, callbacks),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer func() {
+		_ = server.Close()
+	}()
+
+	challenge := sha256.Sum256([]byte(verifier))
+	authURL, err := url.Parse(provider.AuthorizationEndpoint)
+	if err != nil {
+		return fmt.Errorf("invalid authorization endpoint %q: %s", provider.AuthorizationEndpoint, err)
+	}
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", clientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("scope", "openid email profile offline_access")
+	query.Set("state", state)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	fmt.Println("\nOpen this URL in your browser to log in:")
+	fmt.Printf("\n  %s\n\n", authURL.String())
+	if openBrowser {
+		if err := launchBrowser(authURL.String()); err != nil && Debug {
+			fmt.Printf("DEBUG: Failed to open browser: %s\n", err)
+		}
+	}
+	fmt.Println("Waiting for the browser to finish...")
+
+	var callback oauthCallback
+	select {
+	case callback = <-callbacks:
+	case <-time.After(webLoginTimeout):
+		return fmt.Errorf("timed out after %s waiting for the browser login", webLoginTimeout)
+	}
+	if callback.Err != nil {
+		return callback.Err
+	}
+
+	token, err := requestOAuthToken(provider.TokenEndpoint, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {callback.Code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {clientID},
+		"code_verifier": {verifier},
+	})
+	if err != nil {
+		return err
+	}
+
+	config, _ := loadConfig()
+	config.AuthToken = token.AccessToken
+	config.EncKey = token.EncKey
+	config.RefreshToken = token.RefreshToken
+	config.APIKey = "" // A saved key would take precedence over the new session
+	config.TokenURL = provider.TokenEndpoint
+	config.ClientID = clientID
+	if err := saveConfig(config); err != nil {
+		return fmt.Errorf("logged in but failed to save credentials: %s", err)
+	}
+
+	AuthToken = token.AccessToken
+	EncKey = token.EncKey
+
+	fmt.Println("\n✅ Login successful!")
+	if token.EncKey == "" {
+		fmt.Println("\n⚠️  The identity provider did not return an encryption key; log in with your password before deploying ranges.")
+	}
+	warnAPIKeyEnv()
+	fmt.Println("\nWelcome to OpenLabs CLI!")
+	fmt.Println("Use 'openlabs user info' to see your account information.")
+
+	return nil
+}
+
+// discoverOAuthProvider reads the issuer's OpenID discovery document.
+func discoverOAuthProvider(issuer string) (oauthProvider, error) {
+	discoveryURL := strings.TrimRight(issuer, "/") + "/.well-known/openid-configuration"
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	resp, err := httpClient.Get(discoveryURL)
+	if err != nil {
+		return oauthProvider{}, fmt.Errorf("failed to reach identity provider: %s", err)
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return oauthProvider{}, fmt.Errorf("%s does not support web login (discovery returned %s); use --issuer to point at your identity provider", issuer, resp.Status)
+	}
+
+	var provider oauthProvider
+	if err := json.NewDecoder(resp.Body).Decode(&provider); err != nil {
+		return oauthProvider{}, fmt.Errorf("failed to parse discovery document: %s", err)
+	}
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" {
+		return oauthProvider{}, fmt.Errorf("the discovery document at %s is missing the authorization or token endpoint", discoveryURL)
+	}
+	return provider, nil
+}
+
+// oauthCallbackHandler accepts a single redirect from the identity provider.
+func oauthCallbackHandler(state string, callbacks chan<- oauthCallback) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		var callback oauthCallback
+		switch {
+		case query.Get("state") != state:
+			callback.Err = fmt.Errorf("login failed: the browser returned an unexpected state")
+		case query.Get("error") != "":
+			callback.Err = fmt.Errorf("login failed: %s", oauthErrorMessage(query.Get("error"), query.Get("error_description")))
+		case query.Get("code") == "":
+			callback.Err = fmt.Errorf("login failed: the browser did not return an authorization code")
+		default:
+			callback.Code = query.Get("code")
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if callback.Err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "<h1>OpenLabs login failed</h1><p>%s</p>", html.EscapeString(callback.Err.Error()))
+		} else {
+			fmt.Fprint(w, "<h1>OpenLabs login complete</h1><p>You can close this window and return to the terminal.</p>")
+		}
+
+		// Only the first redirect counts
+		select {
+		case callbacks <- callback:
+		default:
+		}
+	})
+	return mux
+}
+
+// requestOAuthToken posts a grant to the token endpoint.
+func requestOAuthToken(tokenURL string, form url.Values) (oauthToken, error) {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	resp, err := httpClient.PostForm(tokenURL, form)
+	if err != nil {
+		return oauthToken{}, fmt.Errorf("failed to reach token endpoint: %s", err)
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return oauthToken{}, fmt.Errorf("failed to read token response: %s", err)
+	}
+
+	var token oauthToken
+	if err := json.Unmarshal(body, &token); err != nil {
+		return oauthToken{}, fmt.Errorf("token request failed with status: %s", resp.Status)
+	}
+	if token.Error != "" {
+		return oauthToken{}, fmt.Errorf("token request failed: %s", oauthErrorMessage(token.Error, token.ErrorDesc))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return oauthToken{}, fmt.Errorf("token request failed with status: %s", resp.Status)
+	}
+	if token.AccessToken == "" {
+		return oauthToken{}, fmt.Errorf("the token endpoint did not return an access token")
+	}
+	return token, nil
+}
+
+// refreshOAuthSession renews a web login with its refresh token.
+func refreshOAuthSession(config Configuration) (authSession, error) {
+	if config.RefreshToken == "" {
+		return authSession{}, fmt.Errorf("no refresh token")
+	}
+
+	clientID := config.ClientID
+	if clientID == "" {
+		clientID = defaultOAuthClientID
+	}
+	token, err := requestOAuthToken(config.TokenURL, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {config.RefreshToken},
+		"client_id":     {clientID},
+	})
+	if err != nil {
+		return authSession{}, err
+	}
+	return authSession{Token: token.AccessToken, EncKey: token.EncKey, RefreshToken: token.RefreshToken}, nil
+}
+
+func oauthErrorMessage(code, description string) string {
+	if description != "" {
+		return fmt.Sprintf("%s (%s)", description, code)
+	}
+	return code
+}
+
+func randomURLString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random value: %s", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// launchBrowser opens a URL with the platform's default browser.
+func launchBrowser(target string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", target)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	default:
+		cmd = exec.Command("xdg-open", target)
+	}
+	return cmd.Start()
+}
@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testIdP is a stand-in identity provider implementing discovery, the
+// authorization endpoint, and the token endpoint with PKCE.
+type testIdP struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu          sync.Mutex
+	challenge   string
+	redirectURI string
+	refreshForm url.Values
+}
+
+func newTestIdP(t *testing.T) *testIdP {
+	t.Helper()
+
+	idp := &testIdP{t: t}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, http.StatusOK, oauthProvider{
+			Issuer:                idp.server.URL,
+			AuthorizationEndpoint: idp.server.URL + "/authorize",
+			TokenEndpoint:         idp.server.URL + "/token",
+		})
+	})
+	mux.HandleFunc("/authorize", idp.authorize)
+	mux.HandleFunc("/token", idp.token)
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+// authorize logs the user in immediately and redirects back to the CLI.
+func (idp *testIdP) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != defaultOAuthClientID || query.Get("response_type") != "code" ||
+		query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		idp.t.Errorf("unexpected authorization request: %s", r.URL.RawQuery)
+	}
+
+	idp.mu.Lock()
+	idp.challenge = query.Get("code_challenge")
+	idp.redirectURI = query.Get("redirect_uri")
+	idp.mu.Unlock()
+
+	http.Redirect(w, r, query.Get("redirect_uri")+"?"+url.Values{
+		"code":  {"auth-code"},
+		"state": {query.Get("state")},
+	}.Encode(), http.StatusFound)
+}
+
+func (idp *testIdP) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeTestJSON(w, http.StatusBadRequest, oauthToken{Error: "invalid_request"})
+		return
+	}
+
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		verifier := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if base64.RawURLEncoding.EncodeToString(verifier[:]) != idp.challenge {
+			writeTestJSON(w, http.StatusBadRequest, oauthToken{Error: "invalid_grant", ErrorDesc: "PKCE verification failed"})
+			return
+		}
+		if r.PostForm.Get("code") != "auth-code" || r.PostForm.Get("redirect_uri") != idp.redirectURI {
+			writeTestJSON(w, http.StatusBadRequest, oauthToken{Error: "invalid_grant"})
+			return
+		}
+		writeTestJSON(w, http.StatusOK, oauthToken{AccessToken: "access-1", RefreshToken: "refresh-1", EncKey: "key-1", TokenType: "Bearer"})
+	case "refresh_token":
+		idp.refreshForm = r.PostForm
+		if r.PostForm.Get("refresh_token") != "refresh-1" {
+			writeTestJSON(w, http.StatusBadRequest, oauthToken{Error: "invalid_grant", ErrorDesc: "refresh token revoked"})
+			return
+		}
+		writeTestJSON(w, http.StatusOK, oauthToken{AccessToken: "access-2", RefreshToken: "refresh-2", EncKey: "key-1", TokenType: "Bearer"})
+	default:
+		writeTestJSON(w, http.StatusBadRequest, oauthToken{Error: "unsupported_grant_type"})
+	}
+}
+
+func writeTestJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// followPrintedURL plays the browser: it reads stdout until webLogin prints
+// the authorization URL, then opens it.
+func followPrintedURL(t *testing.T) func() {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if !strings.HasPrefix(line, "http") {
+				continue
+			}
+			resp, err := http.Get(line)
+			if err != nil {
+				t.Errorf("browser request failed: %s", err)
+				continue
+			}
+			_ = resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("callback returned %s", resp.Status)
+			}
+		}
+	}()
+
+	return func() {
+		os.Stdout = stdout
+		_ = w.Close()
+		<-done
+		_ = r.Close()
+	}
+}
+
+func TestWebLogin(t *testing.T) {
+	newTestAPI(t, http.NotFoundHandler())
+	idp := newTestIdP(t)
+	if err := saveConfig(Configuration{APIKey: "saved-key"}); err != nil {
+		t.Fatal(err)
+	}
+
+	restore := followPrintedURL(t)
+	err := webLogin(idp.server.URL, defaultOAuthClientID, false)
+	restore()
+	if err != nil {
+		t.Fatalf("webLogin() error = %v", err)
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.AuthToken != "access-1" || config.EncKey != "key-1" || config.RefreshToken != "refresh-1" {
+		t.Errorf("saved session = %q/%q/%q", config.AuthToken, config.EncKey, config.RefreshToken)
+	}
+	if config.TokenURL != idp.server.URL+"/token" || config.ClientID != defaultOAuthClientID {
+		t.Errorf("saved token endpoint = %q, client = %q", config.TokenURL, config.ClientID)
+	}
+	if config.APIKey != "" {
+		t.Errorf("saved API key %q was kept and would override the new session", config.APIKey)
+	}
+
+	t.Run("refresh", func(t *testing.T) {
+		session, err := refreshOAuthSession(config)
+		if err != nil {
+			t.Fatalf("refreshOAuthSession() error = %v", err)
+		}
+		want := authSession{Token: "access-2", EncKey: "key-1", RefreshToken: "refresh-2"}
+		if session != want {
+			t.Errorf("refreshOAuthSession() = %+v, want %+v", session, want)
+		}
+		if idp.refreshForm.Get("client_id") != defaultOAuthClientID {
+			t.Errorf("refresh sent client_id %q", idp.refreshForm.Get("client_id"))
+		}
+	})
+
+	t.Run("refresh revoked", func(t *testing.T) {
+		config.RefreshToken = "stale"
+		_, err := refreshOAuthSession(config)
+		if err == nil || !strings.Contains(err.Error(), "refresh token revoked (invalid_grant)") {
+			t.Errorf("refreshOAuthSession() error = %v", err)
+		}
+	})
+
+	t.Run("no refresh token", func(t *testing.T) {
+		config.RefreshToken = ""
+		if _, err := refreshOAuthSession(config); err == nil {
+			t.Error("refreshOAuthSession() succeeded without a refresh token")
+		}
+	})
+}
+
+func TestRequestOAuthTokenChecksVerifier(t *testing.T) {
+	idp := newTestIdP(t)
+	challenge := sha256.Sum256([]byte("right-verifier"))
+	idp.challenge = base64.RawURLEncoding.EncodeToString(challenge[:])
+	idp.redirectURI = "http://127.0.0.1/callback"
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {"auth-code"},
+		"redirect_uri":  {idp.redirectURI},
+		"client_id":     {defaultOAuthClientID},
+		"code_verifier": {"wrong-verifier"},
+	}
+	_, err := requestOAuthToken(idp.server.URL+"/token", form)
+	if err == nil || !strings.Contains(err.Error(), "PKCE verification failed (invalid_grant)") {
+		t.Errorf("requestOAuthToken() with the wrong verifier error = %v", err)
+	}
+
+	form.Set("code_verifier", "right-verifier")
+	token, err := requestOAuthToken(idp.server.URL+"/token", form)
+	if err != nil {
+		t.Fatalf("requestOAuthToken() error = %v", err)
+	}
+	if token.AccessToken != "access-1" {
+		t.Errorf("requestOAuthToken() access token = %q", token.AccessToken)
+	}
+}
+
+func TestDiscoverOAuthProvider(t *testing.T) {
+	idp := newTestIdP(t)
+
+	provider, err := discoverOAuthProvider(idp.server.URL + "/")
+	if err != nil {
+		t.Fatalf("discoverOAuthProvider() error = %v", err)
+	}
+	if provider.AuthorizationEndpoint != idp.server.URL+"/authorize" || provider.TokenEndpoint != idp.server.URL+"/token" {
+		t.Errorf("discoverOAuthProvider() = %+v", provider)
+	}
+
+	_, err = discoverOAuthProvider(idp.server.URL + "/missing")
+	if err == nil || !strings.Contains(err.Error(), "does not support web login") {
+		t.Errorf("discoverOAuthProvider() without a discovery document error = %v", err)
+	}
+
+	incomplete := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, http.StatusOK, oauthProvider{Issuer: "incomplete"})
+	}))
+	defer incomplete.Close()
+	_, err = discoverOAuthProvider(incomplete.URL)
+	if err == nil || !strings.Contains(err.Error(), "missing the authorization or token endpoint") {
+		t.Errorf("discoverOAuthProvider() with missing endpoints error = %v", err)
+	}
+}
+
+func TestOAuthCallbackHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    url.Values
+		wantCode string
+		wantErr  string
+	}{
+		{
+			name:     "code",
+			query:    url.Values{"state": {"expected"}, "code": {"auth-code"}},
+			wantCode: "auth-code",
+		},
+		{
+			name:    "wrong state",
+			query:   url.Values{"state": {"forged"}, "code": {"auth-code"}},
+			wantErr: "unexpected state",
+		},
+		{
+			name:    "error",
+			query:   url.Values{"state": {"expected"}, "error": {"access_denied"}, "error_description": {"The user denied access"}},
+			wantErr: "login failed: The user denied access (access_denied)",
+		},
+		{
+			name:    "no code",
+			query:   url.Values{"state": {"expected"}},
+			wantErr: "did not return an authorization code",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callbacks := make(chan oauthCallback, 1)
+			handler := oauthCallbackHandler("expected", callbacks)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/callback?"+tt.query.Encode(), nil))
+			callback := <-callbacks
+
+			if tt.wantErr != "" {
+				if callback.Err == nil || !strings.Contains(callback.Err.Error(), tt.wantErr) {
+					t.Errorf("callback error = %v, want it to contain %q", callback.Err, tt.wantErr)
+				}
+				if rec.Code != http.StatusBadRequest {
+					t.Errorf("callback status = %d, want %d", rec.Code, http.StatusBadRequest)
+				}
+				return
+			}
+			if callback.Err != nil || callback.Code != tt.wantCode {
+				t.Errorf("callback = %+v, want code %q", callback, tt.wantCode)
+			}
+		})
+	}
+}
@@ -25,20 +25,33 @@ type BlueprintID struct {
 	ID int `json:"id"`
 }
 
+// RangeBlueprint is a full range blueprint including its nested VPCs.
+type RangeBlueprint struct {
+	ID          int            `json:"id,omitempty"`
+	Provider    string         `json:"provider"`
+	Name        string         `json:"name"`
+	VPN         bool           `json:"vpn"`
+	VNC         bool           `json:"vnc"`
+	Description string         `json:"description,omitempty"`
+	VPCs        []VPCBlueprint `json:"vpcs"`
+}
+
 type VPCBlueprint struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
-	CIDR string `json:"cidr"`
+	ID      int               `json:"id,omitempty"`
+	Name    string            `json:"name"`
+	CIDR    string            `json:"cidr"`
+	Subnets []SubnetBlueprint `json:"subnets,omitempty"`
 }
 
 type SubnetBlueprint struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
-	CIDR string `json:"cidr"`
+	ID    int             `json:"id,omitempty"`
+	Name  string          `json:"name"`
+	CIDR  string          `json:"cidr"`
+	Hosts []HostBlueprint `json:"hosts,omitempty"`
 }
 
 type HostBlueprint struct {
-	ID       int      `json:"id"`
+	ID       int      `json:"id,omitempty"`
 	Hostname string   `json:"hostname"`
 	OS       string   `json:"os"`
 	Spec     string   `json:"spec"`
@@ -46,6 +59,19 @@ type HostBlueprint struct {
 	Tags     []string `json:"tags,omitempty"`
 }
 
+// Values accepted by the OpenLabs API for blueprint fields.
+var (
+	blueprintProviders = []string{"aws", "azure"}
+	hostOSes           = []string{
+		"debian_11", "debian_12",
+		"ubuntu_20", "ubuntu_22", "ubuntu_24",
+		"suse_12", "suse_15",
+		"kali",
+		"windows_2016", "windows_2019", "windows_2022",
+	}
+	hostSpecs = []string{"tiny", "small", "medium", "large", "huge"}
+)
+
 // Commands.
 var blueprintsCmd = &cobra.Command{
 	Use:   "blueprints",
@@ -65,7 +91,12 @@ var listRangeBlueprintsCmd = &cobra.Command{
 	Short: "List all range blueprints",
 	Long:  "This command will list all range blueprints from the OpenLabs API.",
 	Run: func(cmd *cobra.Command, args []string) {
-		err := listRangeBlueprints()
+		opts, err := blueprintListOptionsFromFlags(cmd, "range")
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		err = listRangeBlueprints(opts)
 		if err != nil {
 			fmt.Println(err)
 		}
@@ -73,14 +104,14 @@ var listRangeBlueprintsCmd = &cobra.Command{
 }
 
 var getRangeBlueprintCmd = &cobra.Command{
-	Use:   "get [blueprint-id]",
+	Use:   "get [blueprint-id|name]",
 	Short: "Get a range blueprint",
 	Long:  "This command will get a range blueprint from the OpenLabs API.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		id, err := strconv.Atoi(args[0])
+		id, err := resolveBlueprintID("range", args[0])
 		if err != nil {
-			fmt.Println("Error: blueprint ID must be a number")
+			fmt.Printf("Error: %s\n", err)
 			return
 		}
 		err = getRangeBlueprint(id)
@@ -98,25 +129,33 @@ var uploadRangeBlueprintCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		err := uploadRangeBlueprint(args[0])
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
 
 var deleteRangeBlueprintCmd = &cobra.Command{
-	Use:   "delete [blueprint-id]",
+	Use:   "delete [blueprint-id|name]",
 	Short: "Delete a range blueprint",
 	Long:  "This command will delete a range blueprint from the OpenLabs API.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		id, err := strconv.Atoi(args[0])
+		id, err := resolveBlueprintID("range", args[0])
 		if err != nil {
-			fmt.Println("Error: blueprint ID must be a number")
+			fmt.Printf("Error: %s\n", err)
+			return
+		}
+		force, _ := cmd.Flags().GetBool("force")
+		if err := checkBlueprintDeletable("range", id, force); err != nil {
+			fmt.Println(err)
+			return
+		}
+		if !confirmDestructive(fmt.Sprintf("delete range blueprint %d", id), describeBlueprint("range", id)) {
 			return
 		}
 		err = deleteRangeBlueprint(id)
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
@@ -134,7 +173,12 @@ var listVPCBlueprintsCmd = &cobra.Command{
 	Long:  "This command will list all VPC blueprints from the OpenLabs API.",
 	Run: func(cmd *cobra.Command, args []string) {
 		standaloneOnly, _ := cmd.Flags().GetBool("standalone")
-		err := listVPCBlueprints(standaloneOnly)
+		opts, err := blueprintListOptionsFromFlags(cmd, "vpc")
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		err = listVPCBlueprints(standaloneOnly, opts)
 		if err != nil {
 			fmt.Println(err)
 		}
@@ -142,14 +186,14 @@ var listVPCBlueprintsCmd = &cobra.Command{
 }
 
 var getVPCBlueprintCmd = &cobra.Command{
-	Use:   "get [blueprint-id]",
+	Use:   "get [blueprint-id|name]",
 	Short: "Get a VPC blueprint",
 	Long:  "This command will get a VPC blueprint from the OpenLabs API.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		id, err := strconv.Atoi(args[0])
+		id, err := resolveBlueprintID("vpc", args[0])
 		if err != nil {
-			fmt.Println("Error: blueprint ID must be a number")
+			fmt.Printf("Error: %s\n", err)
 			return
 		}
 		err = getVPCBlueprint(id)
@@ -167,25 +211,33 @@ var uploadVPCBlueprintCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		err := uploadVPCBlueprint(args[0])
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
 
 var deleteVPCBlueprintCmd = &cobra.Command{
-	Use:   "delete [blueprint-id]",
+	Use:   "delete [blueprint-id|name]",
 	Short: "Delete a VPC blueprint",
 	Long:  "This command will delete a VPC blueprint from the OpenLabs API.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		id, err := strconv.Atoi(args[0])
+		id, err := resolveBlueprintID("vpc", args[0])
 		if err != nil {
-			fmt.Println("Error: blueprint ID must be a number")
+			fmt.Printf("Error: %s\n", err)
+			return
+		}
+		force, _ := cmd.Flags().GetBool("force")
+		if err := checkBlueprintDeletable("vpc", id, force); err != nil {
+			fmt.Println(err)
+			return
+		}
+		if !confirmDestructive(fmt.Sprintf("delete vpc blueprint %d", id), describeBlueprint("vpc", id)) {
 			return
 		}
 		err = deleteVPCBlueprint(id)
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
@@ -203,7 +255,12 @@ var listSubnetBlueprintsCmd = &cobra.Command{
 	Long:  "This command will list all subnet blueprints from the OpenLabs API.",
 	Run: func(cmd *cobra.Command, args []string) {
 		standaloneOnly, _ := cmd.Flags().GetBool("standalone")
-		err := listSubnetBlueprints(standaloneOnly)
+		opts, err := blueprintListOptionsFromFlags(cmd, "subnet")
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		err = listSubnetBlueprints(standaloneOnly, opts)
 		if err != nil {
 			fmt.Println(err)
 		}
@@ -211,14 +268,14 @@ var listSubnetBlueprintsCmd = &cobra.Command{
 }
 
 var getSubnetBlueprintCmd = &cobra.Command{
-	Use:   "get [blueprint-id]",
+	Use:   "get [blueprint-id|name]",
 	Short: "Get a subnet blueprint",
 	Long:  "This command will get a subnet blueprint from the OpenLabs API.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		id, err := strconv.Atoi(args[0])
+		id, err := resolveBlueprintID("subnet", args[0])
 		if err != nil {
-			fmt.Println("Error: blueprint ID must be a number")
+			fmt.Printf("Error: %s\n", err)
 			return
 		}
 		err = getSubnetBlueprint(id)
@@ -236,25 +293,33 @@ var uploadSubnetBlueprintCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		err := uploadSubnetBlueprint(args[0])
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
 
 var deleteSubnetBlueprintCmd = &cobra.Command{
-	Use:   "delete [blueprint-id]",
+	Use:   "delete [blueprint-id|name]",
 	Short: "Delete a subnet blueprint",
 	Long:  "This command will delete a subnet blueprint from the OpenLabs API.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		id, err := strconv.Atoi(args[0])
+		id, err := resolveBlueprintID("subnet", args[0])
 		if err != nil {
-			fmt.Println("Error: blueprint ID must be a number")
+			fmt.Printf("Error: %s\n", err)
+			return
+		}
+		force, _ := cmd.Flags().GetBool("force")
+		if err := checkBlueprintDeletable("subnet", id, force); err != nil {
+			fmt.Println(err)
+			return
+		}
+		if !confirmDestructive(fmt.Sprintf("delete subnet blueprint %d", id), describeBlueprint("subnet", id)) {
 			return
 		}
 		err = deleteSubnetBlueprint(id)
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
@@ -272,7 +337,12 @@ var listHostBlueprintsCmd = &cobra.Command{
 	Long:  "This command will list all host blueprints from the OpenLabs API.",
 	Run: func(cmd *cobra.Command, args []string) {
 		standaloneOnly, _ := cmd.Flags().GetBool("standalone")
-		err := listHostBlueprints(standaloneOnly)
+		opts, err := blueprintListOptionsFromFlags(cmd, "host")
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		err = listHostBlueprints(standaloneOnly, opts)
 		if err != nil {
 			fmt.Println(err)
 		}
@@ -280,14 +350,14 @@ var listHostBlueprintsCmd = &cobra.Command{
 }
 
 var getHostBlueprintCmd = &cobra.Command{
-	Use:   "get [blueprint-id]",
+	Use:   "get [blueprint-id|name]",
 	Short: "Get a host blueprint",
 	Long:  "This command will get a host blueprint from the OpenLabs API.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		id, err := strconv.Atoi(args[0])
+		id, err := resolveBlueprintID("host", args[0])
 		if err != nil {
-			fmt.Println("Error: blueprint ID must be a number")
+			fmt.Printf("Error: %s\n", err)
 			return
 		}
 		err = getHostBlueprint(id)
@@ -305,45 +375,48 @@ var uploadHostBlueprintCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		err := uploadHostBlueprint(args[0])
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
 
 var deleteHostBlueprintCmd = &cobra.Command{
-	Use:   "delete [blueprint-id]",
+	Use:   "delete [blueprint-id|name]",
 	Short: "Delete a host blueprint",
 	Long:  "This command will delete a host blueprint from the OpenLabs API.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		id, err := strconv.Atoi(args[0])
+		id, err := resolveBlueprintID("host", args[0])
 		if err != nil {
-			fmt.Println("Error: blueprint ID must be a number")
+			fmt.Printf("Error: %s\n", err)
+			return
+		}
+		force, _ := cmd.Flags().GetBool("force")
+		if err := checkBlueprintDeletable("host", id, force); err != nil {
+			fmt.Println(err)
+			return
+		}
+		if !confirmDestructive(fmt.Sprintf("delete host blueprint %d", id), describeBlueprint("host", id)) {
 			return
 		}
 		err = deleteHostBlueprint(id)
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
 
 // Range Blueprints Implementation.
-func listRangeBlueprints() error {
-	client := NewClient()
-	resp, err := client.DoRequest("GET", "/api/v1/blueprints/ranges", nil)
+func listRangeBlueprints(opts blueprintListOptions) error {
+	blueprints, err := fetchRangeBlueprints()
 	if err != nil {
 		return err
 	}
-	defer func() {
-		err := resp.Body.Close()
-		if err != nil {
-			fmt.Printf("Error closing response body: %v\n", err)
-		}
-	}()
 
-	var blueprints []BlueprintHeader
-	if err := ParseResponse(resp, &blueprints); err != nil {
+	blueprints, err = filterBlueprints(blueprints, "range", opts, func(b BlueprintHeader) blueprintFields {
+		return blueprintFields{ID: b.ID, Name: b.Name, Provider: b.Provider, VPN: b.VPN, VNC: b.VNC}
+	})
+	if err != nil {
 		return err
 	}
 
@@ -370,6 +443,26 @@ func listRangeBlueprints() error {
 	return nil
 }
 
+func fetchRangeBlueprints() ([]BlueprintHeader, error) {
+	client := NewClient()
+	resp, err := client.DoRequest("GET", "/api/v1/blueprints/ranges", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var blueprints []BlueprintHeader
+	if err := ParseResponse(resp, &blueprints); err != nil {
+		return nil, err
+	}
+	return blueprints, nil
+}
+
 func getRangeBlueprint(id int) error {
 	client := NewClient()
 	resp, err := client.DoRequest("GET", fmt.Sprintf("/api/v1/blueprints/ranges/%d", id), nil)
@@ -397,6 +490,28 @@ func getRangeBlueprint(id int) error {
 	return nil
 }
 
+// fetchRangeBlueprint retrieves a range blueprint with its full VPC, subnet, and host tree.
+func fetchRangeBlueprint(id int) (RangeBlueprint, error) {
+	client := NewClient()
+	resp, err := client.DoRequest("GET", fmt.Sprintf("/api/v1/blueprints/ranges/%d", id), nil)
+	if err != nil {
+		return RangeBlueprint{}, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var blueprint RangeBlueprint
+	if err := ParseResponse(resp, &blueprint); err != nil {
+		return RangeBlueprint{}, err
+	}
+
+	return blueprint, nil
+}
+
 func uploadRangeBlueprint(filePath string) error {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
@@ -408,6 +523,11 @@ func uploadRangeBlueprint(filePath string) error {
 		return fmt.Errorf("failed to parse blueprint JSON: %s", err)
 	}
 
+	return createRangeBlueprint(blueprintData)
+}
+
+// createRangeBlueprint posts an already parsed range blueprint to the API.
+func createRangeBlueprint(blueprintData interface{}) error {
 	client := NewClient()
 	resp, err := client.DoRequest("POST", "/api/v1/blueprints/ranges", blueprintData)
 	if err != nil {
@@ -456,7 +576,7 @@ func deleteRangeBlueprint(id int) error {
 }
 
 // VPC Blueprints Implementation.
-func listVPCBlueprints(standaloneOnly bool) error {
+func listVPCBlueprints(standaloneOnly bool, opts blueprintListOptions) error {
 	client := NewClient()
 	path := "/api/v1/blueprints/vpcs"
 	if !standaloneOnly {
@@ -479,6 +599,13 @@ func listVPCBlueprints(standaloneOnly bool) error {
 		return err
 	}
 
+	blueprints, err = filterBlueprints(blueprints, "vpc", opts, func(b VPCBlueprint) blueprintFields {
+		return blueprintFields{ID: b.ID, Name: b.Name}
+	})
+	if err != nil {
+		return err
+	}
+
 	if len(blueprints) == 0 {
 		fmt.Println("No VPC blueprints found")
 		return nil
@@ -585,7 +712,7 @@ func deleteVPCBlueprint(id int) error {
 }
 
 // Subnet Blueprints Implementation.
-func listSubnetBlueprints(standaloneOnly bool) error {
+func listSubnetBlueprints(standaloneOnly bool, opts blueprintListOptions) error {
 	client := NewClient()
 	path := "/api/v1/blueprints/subnets"
 	if !standaloneOnly {
@@ -608,6 +735,13 @@ func listSubnetBlueprints(standaloneOnly bool) error {
 		return err
 	}
 
+	blueprints, err = filterBlueprints(blueprints, "subnet", opts, func(b SubnetBlueprint) blueprintFields {
+		return blueprintFields{ID: b.ID, Name: b.Name}
+	})
+	if err != nil {
+		return err
+	}
+
 	if len(blueprints) == 0 {
 		fmt.Println("No subnet blueprints found")
 		return nil
@@ -714,7 +848,7 @@ func deleteSubnetBlueprint(id int) error {
 }
 
 // Host Blueprints Implementation.
-func listHostBlueprints(standaloneOnly bool) error {
+func listHostBlueprints(standaloneOnly bool, opts blueprintListOptions) error {
 	client := NewClient()
 	path := "/api/v1/blueprints/hosts"
 	if !standaloneOnly {
@@ -737,6 +871,13 @@ func listHostBlueprints(standaloneOnly bool) error {
 		return err
 	}
 
+	blueprints, err = filterBlueprints(blueprints, "host", opts, func(b HostBlueprint) blueprintFields {
+		return blueprintFields{ID: b.ID, Name: b.Hostname, Hosts: []HostBlueprint{b}}
+	})
+	if err != nil {
+		return err
+	}
+
 	if len(blueprints) == 0 {
 		fmt.Println("No host blueprints found")
 		return nil
@@ -851,6 +992,28 @@ func init() {
 	listSubnetBlueprintsCmd.Flags().Bool("standalone", true, "List only standalone blueprints (not part of a range/vpc blueprint)")
 	listHostBlueprintsCmd.Flags().Bool("standalone", true, "List only standalone blueprints (not part of a range/vpc/subnet blueprint)")
 
+	// Delete commands refuse to remove blueprints that are in use without --force
+	deleteRangeBlueprintCmd.Flags().Bool("force", false, "Delete the blueprint even if deployed ranges or workspaces depend on it")
+	deleteVPCBlueprintCmd.Flags().Bool("force", false, "Delete the blueprint even if workspaces depend on it")
+	deleteSubnetBlueprintCmd.Flags().Bool("force", false, "Delete the blueprint even if workspaces depend on it")
+	deleteHostBlueprintCmd.Flags().Bool("force", false, "Delete the blueprint even if workspaces depend on it")
+
+	// Filter flags for the list commands
+	addBlueprintListFlags(listRangeBlueprintsCmd, "range")
+	addBlueprintListFlags(listVPCBlueprintsCmd, "vpc")
+	addBlueprintListFlags(listSubnetBlueprintsCmd, "subnet")
+	addBlueprintListFlags(listHostBlueprintsCmd, "host")
+
+	// Shell completion
+	getRangeBlueprintCmd.ValidArgsFunction = completeBlueprintArg("range")
+	deleteRangeBlueprintCmd.ValidArgsFunction = completeBlueprintArg("range")
+	getVPCBlueprintCmd.ValidArgsFunction = completeBlueprintArg("vpc")
+	deleteVPCBlueprintCmd.ValidArgsFunction = completeBlueprintArg("vpc")
+	getSubnetBlueprintCmd.ValidArgsFunction = completeBlueprintArg("subnet")
+	deleteSubnetBlueprintCmd.ValidArgsFunction = completeBlueprintArg("subnet")
+	getHostBlueprintCmd.ValidArgsFunction = completeBlueprintArg("host")
+	deleteHostBlueprintCmd.ValidArgsFunction = completeBlueprintArg("host")
+
 	// Range blueprint commands
 	rangeBlueprintsCmd.AddCommand(listRangeBlueprintsCmd)
 	rangeBlueprintsCmd.AddCommand(getRangeBlueprintCmd)
@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// hoursPerMonth is used to convert monthly storage prices to hourly ones.
+const hoursPerMonth = 730
+
+//go:embed pricing.json
+var defaultPricingData []byte
+
+// Pricing model structures.
+type PricingTable struct {
+	Currency  string                              `json:"currency"`
+	Providers map[string]map[string]RegionPricing `json:"providers"`
+}
+
+type RegionPricing struct {
+	Specs          map[string]InstancePricing `json:"specs"`
+	StorageGBMonth float64                    `json:"storage_gb_month"`
+	Extras         map[string]InstancePricing `json:"extras"`
+}
+
+type InstancePricing struct {
+	InstanceType  string  `json:"instance_type"`
+	Hourly        float64 `json:"hourly"`
+	WindowsHourly float64 `json:"windows_hourly,omitempty"`
+	Size          int     `json:"size,omitempty"`
+}
+
+// HostEstimate is the estimated cost of a single machine in a range.
+type HostEstimate struct {
+	Name         string
+	OS           string
+	Spec         string
+	InstanceType string
+	Size         int
+	Compute      float64
+	Storage      float64
+}
+
+func (e HostEstimate) Total() float64 {
+	return e.Compute + e.Storage
+}
+
+var estimateBlueprintCmd = &cobra.Command{
+	Use:   "estimate [blueprint-id|name|file-path]",
+	Short: "Estimate the cost of deploying a range blueprint",
+	Long: "This command estimates what a range blueprint will cost to run by mapping each host's spec and size, " +
+		"plus the range's jumpbox, VPN, and VNC, to provider instance types and storage. Prices come from a table " +
+		"shipped with the CLI, which can be overridden with --pricing or ~/.openlabs/pricing.json.",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		region, _ := cmd.Flags().GetString("region")
+		hours, _ := cmd.Flags().GetFloat64("hours")
+		pricingPath, _ := cmd.Flags().GetString("pricing")
+
+		if hours <= 0 {
+			fmt.Println("Error: --hours must be greater than zero")
+			return
+		}
+
+		err := estimateRangeBlueprint(args[0], region, hours, pricingPath)
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+// Blueprint Estimate Implementation.
+func estimateRangeBlueprint(source, region string, hours float64, pricingPath string) error {
+	blueprint, err := loadRangeBlueprintSource(source)
+	if err != nil {
+		return err
+	}
+
+	pricing, err := loadPricingTable(pricingPath)
+	if err != nil {
+		return err
+	}
+
+	estimates, err := estimateBlueprintCost(blueprint, pricing, region, hours)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Estimated cost of %s on %s (%s) for %s hours:\n\n",
+		blueprint.Name, blueprint.Provider, region, strconv.FormatFloat(hours, 'f', -1, 64))
+
+	var total float64
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Host", "OS", "Spec", "Instance Type", "Disk", "Compute", "Storage", "Total"})
+	for _, e := range estimates {
+		total += e.Total()
+		table.Append([]string{
+			e.Name,
+			e.OS,
+			e.Spec,
+			e.InstanceType,
+			fmt.Sprintf("%d GB", e.Size),
+			formatCost(e.Compute, pricing.Currency),
+			formatCost(e.Storage, pricing.Currency),
+			formatCost(e.Total(), pricing.Currency),
+		})
+	}
+	table.SetFooter([]string{"", "", "", "", "", "", "Total", formatCost(total, pricing.Currency)})
+	table.Render()
+
+	fmt.Println("\nPrices are on-demand list prices and exclude data transfer and taxes.")
+	return nil
+}
+
+// loadRangeBlueprintSource reads a range blueprint from a local file, or from
+// the API when source is not an existing file.
+func loadRangeBlueprintSource(source string) (RangeBlueprint, error) {
+	var blueprint RangeBlueprint
+
+	if _, err := os.Stat(source); err == nil {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return blueprint, fmt.Errorf("failed to read blueprint file: %s", err)
+		}
+		if err := json.Unmarshal(data, &blueprint); err != nil {
+			return blueprint, fmt.Errorf("failed to parse blueprint JSON: %s", err)
+		}
+		return blueprint, nil
+	}
+
+	id, err := resolveBlueprintID("range", source)
+	if err != nil {
+		return blueprint, fmt.Errorf("%q is not a blueprint file, and %s", source, err)
+	}
+	return fetchRangeBlueprint(id)
+}
+
+// loadPricingTable loads the pricing table shipped with the CLI and applies
+// overrides from path, or from ~/.openlabs/pricing.json when path is empty.
+// Overrides replace the shipped prices one provider region at a time.
+func loadPricingTable(path string) (PricingTable, error) {
+	var pricing PricingTable
+	if err := json.Unmarshal(defaultPricingData, &pricing); err != nil {
+		return pricing, fmt.Errorf("failed to parse built-in pricing table: %s", err)
+	}
+
+	if path == "" {
+		configDir, err := getConfigDir()
+		if err != nil {
+			return pricing, nil
+		}
+		path = filepath.Join(configDir, "pricing.json")
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return pricing, nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return pricing, fmt.Errorf("failed to read pricing file: %s", err)
+	}
+
+	var override PricingTable
+	if err := json.Unmarshal(data, &override); err != nil {
+		return pricing, fmt.Errorf("failed to parse pricing file %s: %s", path, err)
+	}
+
+	if override.Currency != "" {
+		pricing.Currency = override.Currency
+	}
+	for provider, regions := range override.Providers {
+		if pricing.Providers[provider] == nil {
+			pricing.Providers[provider] = map[string]RegionPricing{}
+		}
+		for region, regionPricing := range regions {
+			pricing.Providers[provider][region] = regionPricing
+		}
+	}
+
+	if Debug {
+		fmt.Printf("DEBUG: Applied pricing overrides from %s\n", path)
+	}
+
+	return pricing, nil
+}
+
+// estimateBlueprintCost returns a cost estimate for every machine the blueprint deploys.
+func estimateBlueprintCost(blueprint RangeBlueprint, pricing PricingTable, region string, hours float64) ([]HostEstimate, error) {
+	regions, ok := pricing.Providers[blueprint.Provider]
+	if !ok {
+		return nil, fmt.Errorf("no pricing available for provider %q", blueprint.Provider)
+	}
+	regionPricing, ok := regions[region]
+	if !ok {
+		var known []string
+		for r := range regions {
+			known = append(known, r)
+		}
+		sort.Strings(known)
+		return nil, fmt.Errorf("no pricing available for region %q (known regions: %s)", region, strings.Join(known, ", "))
+	}
+
+	storageHourly := regionPricing.StorageGBMonth / hoursPerMonth
+
+	var estimates []HostEstimate
+	for _, vpc := range blueprint.VPCs {
+		for _, subnet := range vpc.Subnets {
+			for _, host := range subnet.Hosts {
+				instance, ok := regionPricing.Specs[host.Spec]
+				if !ok {
+					return nil, fmt.Errorf("no pricing available for spec %q on host %s", host.Spec, host.Hostname)
+				}
+
+				hourly := instance.Hourly
+				if strings.HasPrefix(host.OS, "windows") && instance.WindowsHourly > 0 {
+					hourly = instance.WindowsHourly
+				}
+
+				estimates = append(estimates, HostEstimate{
+					Name:         host.Hostname,
+					OS:           host.OS,
+					Spec:         host.Spec,
+					InstanceType: instance.InstanceType,
+					Size:         host.Size,
+					Compute:      hourly * hours,
+					Storage:      float64(host.Size) * storageHourly * hours,
+				})
+			}
+		}
+	}
+
+	// Every range gets a jumpbox; VPN and VNC add their own machines
+	extras := []string{"jumpbox"}
+	if blueprint.VPN {
+		extras = append(extras, "vpn")
+	}
+	if blueprint.VNC {
+		extras = append(extras, "vnc")
+	}
+	for _, extra := range extras {
+		instance, ok := regionPricing.Extras[extra]
+		if !ok {
+			continue
+		}
+		estimates = append(estimates, HostEstimate{
+			Name:         fmt.Sprintf("(%s)", extra),
+			InstanceType: instance.InstanceType,
+			Size:         instance.Size,
+			Compute:      instance.Hourly * hours,
+			Storage:      float64(instance.Size) * storageHourly * hours,
+		})
+	}
+
+	return estimates, nil
+}
+
+func formatCost(amount float64, currency string) string {
+	if currency == "" || currency == "USD" {
+		return fmt.Sprintf("$%.2f", amount)
+	}
+	return fmt.Sprintf("%.2f %s", amount, currency)
+}
+
+func init() {
+	estimateBlueprintCmd.Flags().String("region", "us_east_1", "Region the range would be deployed in (e.g., us_east_1)")
+	estimateBlueprintCmd.Flags().Float64("hours", 1, "Number of hours the range would run")
+	estimateBlueprintCmd.Flags().String("pricing", "", "Path to a pricing file overriding the built-in prices")
+
+	_ = estimateBlueprintCmd.RegisterFlagCompletionFunc("region", completeValues(rangeRegions...))
+
+	blueprintsCmd.AddCommand(estimateBlueprintCmd)
+}
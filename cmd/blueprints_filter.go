@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+// blueprintDetailWorkers bounds how many blueprints are fetched at once when a
+// filter needs to look at the hosts inside them.
+const blueprintDetailWorkers = 8
+
+// blueprintListOptions holds the filters shared by the blueprint list commands.
+//
+// The blueprint list endpoints do not take filter parameters, so every filter
+// is applied client-side after the listing is retrieved.
+type blueprintListOptions struct {
+	Name     string
+	Provider string
+	VPN      *bool
+	VNC      *bool
+	Tags     []string
+	OS       string
+	SortBy   string
+	Limit    int
+}
+
+// blueprintFields is the view of a blueprint that filters and sorting work on.
+// Hosts is nil when the listing does not include the blueprint's hosts.
+type blueprintFields struct {
+	ID       int
+	Name     string
+	Provider string
+	VPN      bool
+	VNC      bool
+	Hosts    []HostBlueprint
+}
+
+// blueprintSortFields lists the --sort-by keys supported by each blueprint kind.
+var blueprintSortFields = map[string][]string{
+	"range":  {"name", "id", "provider"},
+	"vpc":    {"name", "id"},
+	"subnet": {"name", "id"},
+	"host":   {"name", "id", "os"},
+}
+
+// addBlueprintListFlags registers the filter flags that apply to a kind of blueprint.
+func addBlueprintListFlags(cmd *cobra.Command, kind string) {
+	cmd.Flags().String("name", "", `Filter by name glob (e.g. "web-*") or a regular expression wrapped in slashes (e.g. "/^web-[0-9]+$/")`)
+	if kind == "range" {
+		cmd.Flags().String("provider", "", "Filter by provider (aws or azure)")
+		cmd.Flags().Bool("vpn", false, "Filter by whether VPN is enabled (use --vpn=false for ranges without VPN)")
+		cmd.Flags().Bool("vnc", false, "Filter by whether VNC is enabled (use --vnc=false for ranges without VNC)")
+	}
+	cmd.Flags().StringSlice("tag", nil, "Only show blueprints containing a host with this tag (repeatable)")
+	cmd.Flags().String("os", "", "Only show blueprints containing a host with this OS (e.g. ubuntu_22)")
+	cmd.Flags().String("sort-by", "", fmt.Sprintf("Sort by field (%s); prefix with - for descending order",
+		strings.Join(blueprintSortFields[kind], ", ")))
+	cmd.Flags().Int("limit", 0, "Maximum number of blueprints to show (0 for no limit)")
+
+	if kind == "range" {
+		_ = cmd.RegisterFlagCompletionFunc("provider", completeValues(blueprintProviders...))
+	}
+	_ = cmd.RegisterFlagCompletionFunc("os", completeValues(hostOSes...))
+	_ = cmd.RegisterFlagCompletionFunc("sort-by", completeValues(blueprintSortFields[kind]...))
+}
+
+// blueprintListOptionsFromFlags reads the filter flags registered by addBlueprintListFlags.
+func blueprintListOptionsFromFlags(cmd *cobra.Command, kind string) (blueprintListOptions, error) {
+	var opts blueprintListOptions
+	flags := cmd.Flags()
+
+	opts.Name, _ = flags.GetString("name")
+	opts.Tags, _ = flags.GetStringSlice("tag")
+	opts.OS, _ = flags.GetString("os")
+	opts.SortBy, _ = flags.GetString("sort-by")
+	opts.Limit, _ = flags.GetInt("limit")
+
+	if kind == "range" {
+		opts.Provider, _ = flags.GetString("provider")
+		if flags.Changed("vpn") {
+			vpn, _ := flags.GetBool("vpn")
+			opts.VPN = &vpn
+		}
+		if flags.Changed("vnc") {
+			vnc, _ := flags.GetBool("vnc")
+			opts.VNC = &vnc
+		}
+	}
+
+	if opts.SortBy != "" {
+		field := strings.TrimPrefix(opts.SortBy, "-")
+		valid := false
+		for _, f := range blueprintSortFields[kind] {
+			if f == field {
+				valid = true
+			}
+		}
+		if !valid {
+			return opts, fmt.Errorf("cannot sort %s blueprints by %q (choose from %s)",
+				kind, field, strings.Join(blueprintSortFields[kind], ", "))
+		}
+	}
+
+	if opts.Limit < 0 {
+		return opts, fmt.Errorf("--limit cannot be negative")
+	}
+
+	return opts, nil
+}
+
+// filterBlueprints applies the list options to a blueprint listing. Blueprints
+// whose hosts are not part of the listing are fetched individually when a host
+// filter is in use.
+func filterBlueprints[T any](items []T, kind string, opts blueprintListOptions, fieldsOf func(T) blueprintFields) ([]T, error) {
+	matchName, err := blueprintNameMatcher(opts.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	type candidate struct {
+		item   T
+		fields blueprintFields
+	}
+
+	var candidates []candidate
+	for _, item := range items {
+		f := fieldsOf(item)
+		if !matchName(f.Name) {
+			continue
+		}
+		if opts.Provider != "" && !strings.EqualFold(f.Provider, opts.Provider) {
+			continue
+		}
+		if opts.VPN != nil && f.VPN != *opts.VPN {
+			continue
+		}
+		if opts.VNC != nil && f.VNC != *opts.VNC {
+			continue
+		}
+		candidates = append(candidates, candidate{item: item, fields: f})
+	}
+
+	if len(opts.Tags) > 0 || opts.OS != "" {
+		// Look up the hosts of every blueprint the listing didn't include them for
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		var fetchErr error
+		sem := make(chan struct{}, blueprintDetailWorkers)
+
+		for i := range candidates {
+			if candidates[i].fields.Hosts != nil {
+				continue
+			}
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(c *candidate) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				hosts, err := fetchBlueprintHosts(kind, c.fields.ID)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil && fetchErr == nil {
+					fetchErr = fmt.Errorf("failed to fetch %s blueprint %d: %s", kind, c.fields.ID, err)
+				}
+				c.fields.Hosts = hosts
+			}(&candidates[i])
+		}
+		wg.Wait()
+
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+
+		var matched []candidate
+		for _, c := range candidates {
+			if hostsMatch(c.fields.Hosts, opts.Tags, opts.OS) {
+				matched = append(matched, c)
+			}
+		}
+		candidates = matched
+	}
+
+	if opts.SortBy != "" {
+		field := strings.TrimPrefix(opts.SortBy, "-")
+		descending := strings.HasPrefix(opts.SortBy, "-")
+
+		sort.SliceStable(candidates, func(i, j int) bool {
+			a, b := candidates[i].fields, candidates[j].fields
+			if descending {
+				a, b = b, a
+			}
+			switch field {
+			case "id":
+				return a.ID < b.ID
+			case "provider":
+				return strings.ToLower(a.Provider) < strings.ToLower(b.Provider)
+			case "os":
+				return firstHostOS(a.Hosts) < firstHostOS(b.Hosts)
+			default:
+				return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+			}
+		})
+	}
+
+	if opts.Limit > 0 && len(candidates) > opts.Limit {
+		candidates = candidates[:opts.Limit]
+	}
+
+	filtered := make([]T, 0, len(candidates))
+	for _, c := range candidates {
+		filtered = append(filtered, c.item)
+	}
+	return filtered, nil
+}
+
+// blueprintNameMatcher builds a case-insensitive matcher from a glob or a
+// /regular expression/.
+func blueprintNameMatcher(pattern string) (func(string) bool, error) {
+	if pattern == "" {
+		return func(string) bool { return true }, nil
+	}
+
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		re, err := regexp.Compile("(?i)" + pattern[1:len(pattern)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid --name regular expression: %s", err)
+		}
+		return re.MatchString, nil
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid --name pattern: %s", err)
+	}
+	lowered := strings.ToLower(pattern)
+	return func(name string) bool {
+		ok, _ := path.Match(lowered, strings.ToLower(name))
+		return ok
+	}, nil
+}
+
+// hostsMatch reports whether any host carries all the tags and, if given, the OS.
+func hostsMatch(hosts []HostBlueprint, tags []string, hostOS string) bool {
+	for _, host := range hosts {
+		if hostOS != "" && !strings.EqualFold(host.OS, hostOS) {
+			continue
+		}
+		if hasAllTags(host.Tags, tags) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAllTags(hostTags, wanted []string) bool {
+	for _, w := range wanted {
+		found := false
+		for _, t := range hostTags {
+			if strings.EqualFold(t, w) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func firstHostOS(hosts []HostBlueprint) string {
+	if len(hosts) == 0 {
+		return ""
+	}
+	return hosts[0].OS
+}
+
+// fetchBlueprintHosts returns every host nested anywhere inside a blueprint.
+func fetchBlueprintHosts(kind string, id int) ([]HostBlueprint, error) {
+	client := NewClient()
+	resp, err := client.DoRequest("GET", fmt.Sprintf("/api/v1/blueprints/%ss/%d", kind, id), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var blueprint struct {
+		VPCs    []VPCBlueprint    `json:"vpcs"`
+		Subnets []SubnetBlueprint `json:"subnets"`
+		Hosts   []HostBlueprint   `json:"hosts"`
+	}
+	if err := ParseResponse(resp, &blueprint); err != nil {
+		return nil, err
+	}
+
+	hosts := []HostBlueprint{}
+	hosts = append(hosts, blueprint.Hosts...)
+	for _, subnet := range blueprint.Subnets {
+		hosts = append(hosts, subnet.Hosts...)
+	}
+	for _, vpc := range blueprint.VPCs {
+		for _, subnet := range vpc.Subnets {
+			hosts = append(hosts, subnet.Hosts...)
+		}
+	}
+	return hosts, nil
+}
@@ -0,0 +1,402 @@
+package cmd
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var hostnamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+var initBlueprintCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Interactively create a range blueprint",
+	Long:  "This command walks you through creating a range blueprint with interactive prompts, previews the topology, and saves it to a file or uploads it to the OpenLabs API.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		upload, _ := cmd.Flags().GetBool("upload")
+
+		err := initRangeBlueprint(output, upload)
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+// Blueprint Wizard Implementation.
+func initRangeBlueprint(output string, upload bool) error {
+	p := newPrompter()
+
+	fmt.Println("\n🧭 Creating a new range blueprint. Press enter to accept the default shown in parentheses.")
+
+	blueprint, err := promptRangeBlueprint(p)
+	if err != nil {
+		return fmt.Errorf("failed to build blueprint: %s", err)
+	}
+
+	fmt.Println("\n📋 Blueprint preview:")
+	printBlueprintTree(blueprint)
+
+	ok, err := p.confirm("\nDoes this look right?", true)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Blueprint discarded")
+		return nil
+	}
+
+	// Without flags, ask what to do with the finished blueprint
+	if output == "" && !upload {
+		output, err = p.ask(fmt.Sprintf("Save blueprint to file, e.g. %s.json (leave empty to skip)", blueprint.Name), "", nil)
+		if err != nil {
+			return err
+		}
+		upload, err = p.confirm("Upload blueprint to OpenLabs now?", false)
+		if err != nil {
+			return err
+		}
+	}
+
+	if output != "" {
+		if err := writeBlueprintFile(p, output, blueprint); err != nil {
+			return err
+		}
+	}
+
+	if upload {
+		return createRangeBlueprint(blueprint)
+	}
+
+	return nil
+}
+
+func promptRangeBlueprint(p *prompter) (RangeBlueprint, error) {
+	var blueprint RangeBlueprint
+	var err error
+
+	blueprint.Name, err = p.ask("Blueprint name", "", requireValue("name"))
+	if err != nil {
+		return blueprint, err
+	}
+	blueprint.Description, err = p.ask("Description (optional)", "", nil)
+	if err != nil {
+		return blueprint, err
+	}
+	blueprint.Provider, err = p.choose("Provider", blueprintProviders, blueprintProviders[0])
+	if err != nil {
+		return blueprint, err
+	}
+	blueprint.VPN, err = p.confirm("Enable VPN access?", false)
+	if err != nil {
+		return blueprint, err
+	}
+	blueprint.VNC, err = p.confirm("Enable VNC access?", false)
+	if err != nil {
+		return blueprint, err
+	}
+
+	for {
+		fmt.Printf("\n🌐 VPC %d\n", len(blueprint.VPCs)+1)
+		vpc, err := promptVPCBlueprint(p, blueprint.VPCs)
+		if err != nil {
+			return blueprint, err
+		}
+		blueprint.VPCs = append(blueprint.VPCs, vpc)
+
+		more, err := p.confirm("Add another VPC?", false)
+		if err != nil {
+			return blueprint, err
+		}
+		if !more {
+			return blueprint, nil
+		}
+	}
+}
+
+func promptVPCBlueprint(p *prompter, existing []VPCBlueprint) (VPCBlueprint, error) {
+	var vpc VPCBlueprint
+	var err error
+
+	var taken []string
+	for _, v := range existing {
+		taken = append(taken, v.CIDR)
+	}
+
+	vpc.Name, err = p.ask("VPC name", fmt.Sprintf("vpc-%d", len(existing)+1), requireValue("name"))
+	if err != nil {
+		return vpc, err
+	}
+	vpc.CIDR, err = p.ask("VPC CIDR", suggestVPCCIDR(taken), func(s string) error {
+		return validateCIDR(s, "", taken)
+	})
+	if err != nil {
+		return vpc, err
+	}
+
+	for {
+		fmt.Printf("\n  🔀 Subnet %d in %s\n", len(vpc.Subnets)+1, vpc.Name)
+		subnet, err := promptSubnetBlueprint(p, vpc)
+		if err != nil {
+			return vpc, err
+		}
+		vpc.Subnets = append(vpc.Subnets, subnet)
+
+		more, err := p.confirm("Add another subnet to this VPC?", false)
+		if err != nil {
+			return vpc, err
+		}
+		if !more {
+			return vpc, nil
+		}
+	}
+}
+
+func promptSubnetBlueprint(p *prompter, vpc VPCBlueprint) (SubnetBlueprint, error) {
+	var subnet SubnetBlueprint
+	var err error
+
+	var taken []string
+	for _, s := range vpc.Subnets {
+		taken = append(taken, s.CIDR)
+	}
+
+	subnet.Name, err = p.ask("Subnet name", fmt.Sprintf("%s-subnet-%d", vpc.Name, len(vpc.Subnets)+1), requireValue("name"))
+	if err != nil {
+		return subnet, err
+	}
+	subnet.CIDR, err = p.ask("Subnet CIDR", suggestSubnetCIDR(vpc.CIDR, taken), func(s string) error {
+		return validateCIDR(s, vpc.CIDR, taken)
+	})
+	if err != nil {
+		return subnet, err
+	}
+
+	for {
+		fmt.Printf("\n    🖥️  Host %d in %s\n", len(subnet.Hosts)+1, subnet.Name)
+		host, err := promptHostBlueprint(p, subnet.Hosts)
+		if err != nil {
+			return subnet, err
+		}
+		subnet.Hosts = append(subnet.Hosts, host)
+
+		more, err := p.confirm("Add another host to this subnet?", false)
+		if err != nil {
+			return subnet, err
+		}
+		if !more {
+			return subnet, nil
+		}
+	}
+}
+
+func promptHostBlueprint(p *prompter, existing []HostBlueprint) (HostBlueprint, error) {
+	var host HostBlueprint
+	var err error
+
+	host.Hostname, err = p.ask("Hostname", fmt.Sprintf("host-%d", len(existing)+1), func(s string) error {
+		if !hostnamePattern.MatchString(s) {
+			return fmt.Errorf("hostname may only contain lowercase letters, digits, and hyphens")
+		}
+		for _, h := range existing {
+			if h.Hostname == s {
+				return fmt.Errorf("hostname %q is already used in this subnet", s)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return host, err
+	}
+	host.OS, err = p.choose("Operating system", hostOSes, "")
+	if err != nil {
+		return host, err
+	}
+	host.Spec, err = p.choose("Spec", hostSpecs, hostSpecs[0])
+	if err != nil {
+		return host, err
+	}
+	minSize := minHostDiskSize(host.OS)
+	host.Size, err = p.askInt("Disk size in GB", minSize, minSize)
+	if err != nil {
+		return host, err
+	}
+
+	tags, err := p.ask("Tags (comma-separated, optional)", "", nil)
+	if err != nil {
+		return host, err
+	}
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			host.Tags = append(host.Tags, tag)
+		}
+	}
+
+	return host, nil
+}
+
+func writeBlueprintFile(p *prompter, path string, blueprint RangeBlueprint) error {
+	if _, err := os.Stat(path); err == nil {
+		overwrite, err := p.confirm(fmt.Sprintf("%s already exists. Overwrite?", path), false)
+		if err != nil {
+			return err
+		}
+		if !overwrite {
+			fmt.Println("Skipped writing blueprint file")
+			return nil
+		}
+	}
+
+	data, err := json.MarshalIndent(blueprint, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format blueprint: %s", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write blueprint file: %s", err)
+	}
+
+	fmt.Printf("Blueprint written to %s\n", path)
+	return nil
+}
+
+// printBlueprintTree prints the topology of a range blueprint.
+func printBlueprintTree(blueprint RangeBlueprint) {
+	fmt.Printf("\n%s (provider: %s, VPN: %t, VNC: %t)\n", blueprint.Name, blueprint.Provider, blueprint.VPN, blueprint.VNC)
+	for i, vpc := range blueprint.VPCs {
+		vpcBranch, vpcIndent := treeBranch(i == len(blueprint.VPCs)-1)
+		fmt.Printf("%sVPC %s %s\n", vpcBranch, vpc.Name, vpc.CIDR)
+
+		for j, subnet := range vpc.Subnets {
+			subnetBranch, subnetIndent := treeBranch(j == len(vpc.Subnets)-1)
+			fmt.Printf("%s%sSubnet %s %s\n", vpcIndent, subnetBranch, subnet.Name, subnet.CIDR)
+
+			for k, host := range subnet.Hosts {
+				hostBranch, _ := treeBranch(k == len(subnet.Hosts)-1)
+				tags := ""
+				if len(host.Tags) > 0 {
+					tags = " [" + strings.Join(host.Tags, ", ") + "]"
+				}
+				fmt.Printf("%s%s%s%s %s %s %dGB%s\n", vpcIndent, subnetIndent, hostBranch,
+					host.Hostname, host.OS, host.Spec, host.Size, tags)
+			}
+		}
+	}
+}
+
+func treeBranch(last bool) (string, string) {
+	if last {
+		return "└── ", "    "
+	}
+	return "├── ", "│   "
+}
+
+func requireValue(field string) func(string) error {
+	return func(s string) error {
+		if s == "" {
+			return fmt.Errorf("%s is required", field)
+		}
+		return nil
+	}
+}
+
+// minHostDiskSize returns the smallest disk in GB that the OS image fits on.
+func minHostDiskSize(hostOS string) int {
+	switch {
+	case strings.HasPrefix(hostOS, "windows"):
+		return 32
+	case hostOS == "kali":
+		return 32
+	default:
+		return 8
+	}
+}
+
+// validateCIDR checks that cidr is an IPv4 network address, lies inside parent
+// (when given), and does not overlap any of the taken networks.
+func validateCIDR(cidr, parent string, taken []string) error {
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil || ip.To4() == nil {
+		return fmt.Errorf("%q is not a valid IPv4 CIDR", cidr)
+	}
+	if !ip.Equal(network.IP) {
+		return fmt.Errorf("%q is not a network address, did you mean %s?", cidr, network)
+	}
+
+	if parent != "" {
+		_, parentNet, err := net.ParseCIDR(parent)
+		if err == nil {
+			parentOnes, _ := parentNet.Mask.Size()
+			ones, _ := network.Mask.Size()
+			if !parentNet.Contains(network.IP) || ones < parentOnes {
+				return fmt.Errorf("%s is not inside %s", cidr, parent)
+			}
+		}
+	}
+
+	for _, t := range taken {
+		if _, takenNet, err := net.ParseCIDR(t); err == nil && cidrsOverlap(network, takenNet) {
+			return fmt.Errorf("%s overlaps %s", cidr, t)
+		}
+	}
+
+	return nil
+}
+
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// suggestVPCCIDR returns the first 10.x.0.0/16 network not already taken.
+func suggestVPCCIDR(taken []string) string {
+	for i := 0; i < 256; i++ {
+		candidate := fmt.Sprintf("10.%d.0.0/16", i)
+		if validateCIDR(candidate, "", taken) == nil {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// suggestSubnetCIDR returns the first free /24 inside the VPC, skipping the
+// VPC's first block to match the layout of the example blueprints.
+func suggestSubnetCIDR(vpcCIDR string, taken []string) string {
+	_, vpcNet, err := net.ParseCIDR(vpcCIDR)
+	if err != nil || vpcNet.IP.To4() == nil {
+		return ""
+	}
+
+	ones, _ := vpcNet.Mask.Size()
+	if ones >= 24 {
+		if validateCIDR(vpcNet.String(), vpcCIDR, taken) == nil {
+			return vpcNet.String()
+		}
+		return ""
+	}
+
+	base := binary.BigEndian.Uint32(vpcNet.IP.To4())
+	blocks := uint32(1) << (24 - ones)
+	for i := uint32(1); i < blocks; i++ {
+		ip := make(net.IP, 4)
+		binary.BigEndian.PutUint32(ip, base+i<<8)
+		candidate := fmt.Sprintf("%s/24", ip)
+		if validateCIDR(candidate, vpcCIDR, taken) == nil {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func init() {
+	initBlueprintCmd.Flags().StringP("output", "o", "", "File to write the blueprint to")
+	initBlueprintCmd.Flags().Bool("upload", false, "Upload the blueprint to the OpenLabs API when done")
+
+	blueprintsCmd.AddCommand(initBlueprintCmd)
+}
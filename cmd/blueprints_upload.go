@@ -0,0 +1,453 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// blueprintFile is a blueprint file found by upload-dir.
+type blueprintFile struct {
+	Path   string
+	Kind   string
+	Hash   string
+	Data   interface{}
+	Status string
+	ID     int
+	Err    error
+}
+
+// uploadRecord remembers what was last uploaded from a file.
+type uploadRecord struct {
+	Kind string `json:"kind"`
+	Hash string `json:"hash"`
+	ID   int    `json:"id"`
+}
+
+// uploadIndex maps API URL to absolute file path to the last upload from that file.
+type uploadIndex map[string]map[string]uploadRecord
+
+var uploadDirBlueprintsCmd = &cobra.Command{
+	Use:   "upload-dir [directory]",
+	Short: "Upload every blueprint in a directory",
+	Long: "This command uploads all JSON blueprint files in a directory. The kind of each blueprint (range, vpc, " +
+		"subnet, or host) is detected from its content, every file is validated before anything is uploaded, and " +
+		"files that have not changed since they were last uploaded are skipped.",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		workers, _ := cmd.Flags().GetInt("workers")
+		recursive, _ := cmd.Flags().GetBool("recursive")
+		force, _ := cmd.Flags().GetBool("force")
+
+		if workers < 1 {
+			fmt.Println("Error: --workers must be at least 1")
+			return
+		}
+
+		err := uploadBlueprintDir(args[0], workers, recursive, force)
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+// Blueprint Directory Upload Implementation.
+func uploadBlueprintDir(dir string, workers int, recursive, force bool) error {
+	paths, err := findBlueprintFiles(dir, recursive)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		fmt.Printf("No blueprint files found in %s\n", dir)
+		return nil
+	}
+
+	// Validate everything before uploading anything
+	var files []*blueprintFile
+	var invalid int
+	for _, path := range paths {
+		file, err := readBlueprintFile(path)
+		if err != nil {
+			fmt.Printf("❌ %s: %s\n", path, err)
+			invalid++
+			continue
+		}
+		files = append(files, file)
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d blueprint files are invalid; nothing was uploaded", invalid, len(paths))
+	}
+
+	index := loadUploadIndex()
+	uploaded := index[APIURL]
+	if uploaded == nil {
+		uploaded = map[string]uploadRecord{}
+		index[APIURL] = uploaded
+	}
+
+	// Decide what is unchanged before any worker writes to the index
+	var pending []*blueprintFile
+	for _, file := range files {
+		if record, ok := uploaded[file.Path]; ok && !force && record.Hash == file.Hash && record.Kind == file.Kind {
+			file.Status = "unchanged"
+			file.ID = record.ID
+			continue
+		}
+		pending = append(pending, file)
+	}
+
+	if DryRun {
+		fmt.Printf("The following %d blueprint(s) would be uploaded:\n", len(pending))
+		for _, file := range pending {
+			fmt.Printf("  %s (%s)\n", file.Path, file.Kind)
+		}
+		fmt.Println("Dry run: no blueprints were uploaded")
+		return nil
+	}
+
+	fmt.Printf("Uploading %d blueprints with %d workers...\n", len(files), workers)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	sem := make(chan struct{}, workers)
+	for _, file := range pending {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(file *blueprintFile) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			id, err := postBlueprint(file.Kind, file.Data)
+			if err != nil {
+				file.Status = "failed"
+				file.Err = err
+				return
+			}
+
+			file.Status = "created"
+			file.ID = id
+
+			mu.Lock()
+			uploaded[file.Path] = uploadRecord{Kind: file.Kind, Hash: file.Hash, ID: id}
+			mu.Unlock()
+		}(file)
+	}
+	wg.Wait()
+
+	if err := saveUploadIndex(index); err != nil {
+		fmt.Printf("Warning: failed to save upload index: %s\n", err)
+	}
+
+	var failed int
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"File", "Kind", "Status", "ID", "Error"})
+	for _, file := range files {
+		id := ""
+		if file.ID != 0 {
+			id = strconv.Itoa(file.ID)
+		}
+		errMsg := ""
+		if file.Err != nil {
+			errMsg = file.Err.Error()
+			failed++
+		}
+		table.Append([]string{file.Path, file.Kind, file.Status, id, errMsg})
+	}
+	table.Render()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d blueprints failed to upload", failed, len(files))
+	}
+	return nil
+}
+
+// findBlueprintFiles lists the .json files in dir, sorted by path.
+func findBlueprintFiles(dir string, recursive bool) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.EqualFold(filepath.Ext(path), ".json") {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blueprint directory: %s", err)
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// readBlueprintFile loads, identifies, and validates a single blueprint file.
+func readBlueprintFile(path string) (*blueprintFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blueprint file: %s", err)
+	}
+
+	kind, err := detectBlueprintKind(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateBlueprint(kind, data); err != nil {
+		return nil, fmt.Errorf("invalid %s blueprint: %s", kind, err)
+	}
+
+	var blueprintData interface{}
+	if err := json.Unmarshal(data, &blueprintData); err != nil {
+		return nil, fmt.Errorf("failed to parse blueprint JSON: %s", err)
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		absPath = path
+	}
+
+	sum := sha256.Sum256(data)
+	return &blueprintFile{
+		Path: absPath,
+		Kind: kind,
+		Hash: hex.EncodeToString(sum[:]),
+		Data: blueprintData,
+	}, nil
+}
+
+// detectBlueprintKind tells range, VPC, subnet, and host blueprints apart by
+// the fields only they carry.
+func detectBlueprintKind(data []byte) (string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", fmt.Errorf("failed to parse blueprint JSON: %s", err)
+	}
+
+	has := func(key string) bool {
+		_, ok := fields[key]
+		return ok
+	}
+
+	switch {
+	case has("vpcs") || has("provider"):
+		return "range", nil
+	case has("subnets"):
+		return "vpc", nil
+	case has("hosts"):
+		return "subnet", nil
+	case has("hostname"):
+		return "host", nil
+	default:
+		return "", fmt.Errorf("cannot tell what kind of blueprint this is")
+	}
+}
+
+// validateBlueprint checks a blueprint of the given kind for the mistakes the
+// API would otherwise reject.
+func validateBlueprint(kind string, data []byte) error {
+	switch kind {
+	case "range":
+		var blueprint RangeBlueprint
+		if err := json.Unmarshal(data, &blueprint); err != nil {
+			return err
+		}
+		return validateRangeBlueprint(blueprint)
+	case "vpc":
+		var blueprint VPCBlueprint
+		if err := json.Unmarshal(data, &blueprint); err != nil {
+			return err
+		}
+		return validateVPCBlueprint(blueprint, nil)
+	case "subnet":
+		var blueprint SubnetBlueprint
+		if err := json.Unmarshal(data, &blueprint); err != nil {
+			return err
+		}
+		return validateSubnetBlueprint(blueprint, "", nil)
+	case "host":
+		var blueprint HostBlueprint
+		if err := json.Unmarshal(data, &blueprint); err != nil {
+			return err
+		}
+		return validateHostBlueprint(blueprint)
+	default:
+		return fmt.Errorf("unknown blueprint kind %q", kind)
+	}
+}
+
+func validateRangeBlueprint(blueprint RangeBlueprint) error {
+	if blueprint.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if !containsString(blueprintProviders, blueprint.Provider) {
+		return fmt.Errorf("provider must be one of %s", strings.Join(blueprintProviders, ", "))
+	}
+	if len(blueprint.VPCs) == 0 {
+		return fmt.Errorf("at least one VPC is required")
+	}
+
+	var taken []string
+	for i, vpc := range blueprint.VPCs {
+		if err := validateVPCBlueprint(vpc, taken); err != nil {
+			return fmt.Errorf("vpcs[%d]: %s", i, err)
+		}
+		taken = append(taken, vpc.CIDR)
+	}
+	return nil
+}
+
+func validateVPCBlueprint(vpc VPCBlueprint, taken []string) error {
+	if vpc.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if err := validateCIDR(vpc.CIDR, "", taken); err != nil {
+		return err
+	}
+	if len(vpc.Subnets) == 0 {
+		return fmt.Errorf("at least one subnet is required")
+	}
+
+	var subnetsTaken []string
+	for i, subnet := range vpc.Subnets {
+		if err := validateSubnetBlueprint(subnet, vpc.CIDR, subnetsTaken); err != nil {
+			return fmt.Errorf("subnets[%d]: %s", i, err)
+		}
+		subnetsTaken = append(subnetsTaken, subnet.CIDR)
+	}
+	return nil
+}
+
+func validateSubnetBlueprint(subnet SubnetBlueprint, vpcCIDR string, taken []string) error {
+	if subnet.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if err := validateCIDR(subnet.CIDR, vpcCIDR, taken); err != nil {
+		return err
+	}
+	if len(subnet.Hosts) == 0 {
+		return fmt.Errorf("at least one host is required")
+	}
+
+	hostnames := map[string]bool{}
+	for i, host := range subnet.Hosts {
+		if err := validateHostBlueprint(host); err != nil {
+			return fmt.Errorf("hosts[%d]: %s", i, err)
+		}
+		if hostnames[host.Hostname] {
+			return fmt.Errorf("hosts[%d]: hostname %q is used more than once", i, host.Hostname)
+		}
+		hostnames[host.Hostname] = true
+	}
+	return nil
+}
+
+func validateHostBlueprint(host HostBlueprint) error {
+	if !hostnamePattern.MatchString(host.Hostname) {
+		return fmt.Errorf("invalid hostname %q", host.Hostname)
+	}
+	if !containsString(hostOSes, host.OS) {
+		return fmt.Errorf("unknown os %q", host.OS)
+	}
+	if !containsString(hostSpecs, host.Spec) {
+		return fmt.Errorf("unknown spec %q", host.Spec)
+	}
+	if minSize := minHostDiskSize(host.OS); host.Size < minSize {
+		return fmt.Errorf("size must be at least %d GB for %s", minSize, host.OS)
+	}
+	return nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// postBlueprint uploads a blueprint of the given kind and returns its new ID.
+func postBlueprint(kind string, blueprintData interface{}) (int, error) {
+	client := NewClient()
+	resp, err := client.DoRequest("POST", fmt.Sprintf("/api/v1/blueprints/%ss", kind), blueprintData)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var result BlueprintID
+	if err := ParseResponse(resp, &result); err != nil {
+		return 0, err
+	}
+	return result.ID, nil
+}
+
+func getUploadIndexPath() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "uploads.json"), nil
+}
+
+// loadUploadIndex reads the upload index, starting fresh if it is missing or unreadable.
+func loadUploadIndex() uploadIndex {
+	index := uploadIndex{}
+
+	path, err := getUploadIndexPath()
+	if err != nil {
+		return index
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return index
+	}
+	if err := json.Unmarshal(data, &index); err != nil && Debug {
+		fmt.Printf("DEBUG: Ignoring unreadable upload index: %s\n", err)
+	}
+	return index
+}
+
+func saveUploadIndex(index uploadIndex) error {
+	path, err := getUploadIndexPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func init() {
+	uploadDirBlueprintsCmd.Flags().Int("workers", 4, "Number of blueprints to upload at the same time")
+	uploadDirBlueprintsCmd.Flags().BoolP("recursive", "r", false, "Also upload blueprints in subdirectories")
+	uploadDirBlueprintsCmd.Flags().Bool("force", false, "Upload files even if they have not changed since the last upload")
+
+	blueprintsCmd.AddCommand(uploadDirBlueprintsCmd)
+}
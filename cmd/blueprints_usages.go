@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// blueprintShare is a workspace that a blueprint is shared with.
+type blueprintShare struct {
+	Workspace  Workspace
+	Permission string
+}
+
+// blueprintDependents are the resources that rely on a blueprint.
+type blueprintDependents struct {
+	Ranges     []DeployedRangeHeader
+	Workspaces []blueprintShare
+}
+
+func (d blueprintDependents) empty() bool {
+	return len(d.Ranges) == 0 && len(d.Workspaces) == 0
+}
+
+var rangeBlueprintUsagesCmd = &cobra.Command{
+	Use:   "usages [blueprint-id|name]",
+	Short: "Show what depends on a range blueprint",
+	Long:  "This command lists the deployed ranges created from a range blueprint and the workspaces it is shared with.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := resolveBlueprintID("range", args[0])
+		if err != nil {
+			fmt.Printf("Error: %s\n", err)
+			return
+		}
+		err = showBlueprintUsages("range", id)
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+// Blueprint Usages Implementation.
+func showBlueprintUsages(kind string, id int) error {
+	deps, err := findBlueprintDependents(kind, id)
+	if err != nil {
+		return err
+	}
+
+	if deps.empty() {
+		fmt.Printf("No deployed ranges or workspaces depend on %s blueprint %d\n", kind, id)
+		return nil
+	}
+
+	printBlueprintDependents(deps)
+	return nil
+}
+
+// findBlueprintDependents finds deployed ranges built from a blueprint and
+// workspaces it is shared with. Only range blueprints can have deployed ranges.
+func findBlueprintDependents(kind string, id int) (blueprintDependents, error) {
+	var deps blueprintDependents
+
+	if kind == "range" {
+		ranges, err := fetchRanges()
+		if err != nil {
+			return deps, fmt.Errorf("failed to list deployed ranges: %s", err)
+		}
+		for _, r := range ranges {
+			if r.BlueprintID == id {
+				deps.Ranges = append(deps.Ranges, r)
+			}
+		}
+	}
+
+	workspaces, err := fetchWorkspaces()
+	if err != nil {
+		return deps, fmt.Errorf("failed to list workspaces: %s", err)
+	}
+	for _, w := range workspaces {
+		shared, err := fetchWorkspaceBlueprints(w.ID)
+		if err != nil {
+			return deps, fmt.Errorf("failed to list blueprints of workspace %d: %s", w.ID, err)
+		}
+		for _, b := range shared {
+			if b.BlueprintID == id && b.BlueprintType == kind {
+				deps.Workspaces = append(deps.Workspaces, blueprintShare{Workspace: w, Permission: b.Permission})
+			}
+		}
+	}
+
+	return deps, nil
+}
+
+func printBlueprintDependents(deps blueprintDependents) {
+	if len(deps.Ranges) > 0 {
+		fmt.Println("Deployed ranges:")
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"ID", "Name", "State", "Created At"})
+		for _, r := range deps.Ranges {
+			table.Append([]string{
+				strconv.Itoa(r.ID),
+				r.Name,
+				r.State,
+				r.CreatedAt.Format(time.RFC3339),
+			})
+		}
+		table.Render()
+	}
+
+	if len(deps.Workspaces) > 0 {
+		fmt.Println("Shared with workspaces:")
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"ID", "Name", "Permission"})
+		for _, s := range deps.Workspaces {
+			table.Append([]string{
+				strconv.Itoa(s.Workspace.ID),
+				s.Workspace.Name,
+				s.Permission,
+			})
+		}
+		table.Render()
+	}
+}
+
+// checkBlueprintDeletable refuses to delete a blueprint that is still in use
+// unless force is set.
+func checkBlueprintDeletable(kind string, id int, force bool) error {
+	if force {
+		return nil
+	}
+
+	deps, err := findBlueprintDependents(kind, id)
+	if err != nil {
+		return fmt.Errorf("could not check whether %s blueprint %d is in use: %s\nUse --force to delete it anyway", kind, id, err)
+	}
+	if deps.empty() {
+		return nil
+	}
+
+	fmt.Printf("⚠️  %s blueprint %d is still in use:\n", kind, id)
+	printBlueprintDependents(deps)
+	return fmt.Errorf("refusing to delete %s blueprint %d while it is in use; use --force to delete it anyway", kind, id)
+}
+
+func init() {
+	rangeBlueprintUsagesCmd.ValidArgsFunction = completeBlueprintArg("range")
+
+	rangeBlueprintsCmd.AddCommand(rangeBlueprintUsagesCmd)
+}
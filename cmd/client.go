@@ -3,20 +3,35 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
+	"os"
 	"strings"
 	"time"
 )
 
+// errDryRun is returned instead of a response when --dry-run stops a request.
+var errDryRun = errors.New("dry run: request not sent")
+
+// printError prints the error a command failed with. A request stopped by
+// --dry-run has already been printed and is not a failure.
+func printError(err error) {
+	if errors.Is(err, errDryRun) {
+		return
+	}
+	fmt.Println(err)
+}
+
 // Client represents a client for the OpenLabs API.
 type Client struct {
 	BaseURL     string
 	AuthToken   string
 	EncKey      string
+	APIKey      string
 	HTTPClient  *http.Client
 	CookieJar   http.CookieJar
 	LastCookies []*http.Cookie
@@ -48,6 +63,15 @@ func NewClient() *Client {
 		}
 	}
 
+	// An API key replaces the session entirely, so there is nothing to renew
+	currentAPIKey := os.Getenv(apiKeyEnv)
+	if currentAPIKey == "" && err == nil {
+		currentAPIKey = config.APIKey
+	}
+	if currentAPIKey != "" {
+		currentAuthToken = ""
+	}
+
 	if Debug {
 		fmt.Printf("DEBUG: Creating new client with auth token length: %d\n", len(currentAuthToken))
 		fmt.Printf("DEBUG: Creating new client with enc key length: %d\n", len(currentEncKey))
@@ -57,13 +81,37 @@ func NewClient() *Client {
 		BaseURL:    APIURL,
 		AuthToken:  currentAuthToken,
 		EncKey:     currentEncKey,
+		APIKey:     currentAPIKey,
 		HTTPClient: httpClient,
 		CookieJar:  jar,
 	}
 }
 
-// DoRequest performs an HTTP request to the OpenLabs API.
+// DoRequest performs an HTTP request to the OpenLabs API. Sessions that are
+// about to expire, or that the API rejects, are renewed when possible.
 func (c *Client) DoRequest(method, path string, body interface{}) (*http.Response, error) {
+	if err := c.ensureFreshSession(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.send(method, path, body)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || c.AuthToken == "" {
+		return resp, err
+	}
+
+	// The session may have been revoked or expired early; renew it and retry once
+	rejected := c.AuthToken
+	if !c.renewSession() || c.AuthToken == rejected {
+		return resp, nil
+	}
+	if err := resp.Body.Close(); err != nil {
+		fmt.Printf("Error closing response body: %v\n", err)
+	}
+	return c.send(method, path, body)
+}
+
+// send performs a single HTTP request to the OpenLabs API.
+func (c *Client) send(method, path string, body interface{}) (*http.Response, error) {
 	requestURL := fmt.Sprintf("%s%s", c.BaseURL, path)
 
 	var reqBody io.Reader
@@ -89,6 +137,15 @@ func (c *Client) DoRequest(method, path string, body interface{}) (*http.Respons
 	// We still need to manually add cookies because HTTP-only cookies from a response won't be accessible to Go
 	parsedURL, _ := url.Parse(requestURL)
 
+	// API keys are sent as a single header instead of the session cookies
+	if c.APIKey != "" {
+		req.Header.Set(apiKeyHeader, c.APIKey)
+
+		if Debug {
+			fmt.Printf("DEBUG: Added %s header\n", apiKeyHeader)
+		}
+	}
+
 	// Add access token cookie if available
 	if c.AuthToken != "" {
 		// Try multiple cookie names to ensure compatibility
@@ -111,7 +168,7 @@ func (c *Client) DoRequest(method, path string, body interface{}) (*http.Respons
 			fmt.Printf("DEBUG: Added auth cookies with token: %s\n", c.AuthToken)
 			fmt.Printf("DEBUG: Token length: %d\n", len(c.AuthToken))
 		}
-	} else {
+	} else if c.APIKey == "" {
 		if Debug {
 			fmt.Println("DEBUG: No auth token available for cookies")
 		}
@@ -172,6 +229,12 @@ func (c *Client) DoRequest(method, path string, body interface{}) (*http.Respons
 		fmt.Printf("---------------------\n")
 	}
 
+	// Reads are still sent so dry runs can show what would change
+	if DryRun && method != http.MethodGet && method != http.MethodHead {
+		printDryRunRequest(method, requestURL, bodyStr)
+		return nil, errDryRun
+	}
+
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %s", err)
@@ -222,6 +285,17 @@ func ParseResponse(resp *http.Response, result interface{}) error {
 		fmt.Printf("---------------------------\n")
 	}
 
+	// A rejected token means the session is over; say so instead of showing a bare 401
+	if resp.StatusCode == http.StatusUnauthorized && resp.Request != nil && !isLoginPath(resp.Request.URL.Path) {
+		if resp.Request.Header.Get(apiKeyHeader) != "" {
+			return errAPIKeyRejected
+		}
+		if resp.Request.Header.Get("Authorization") != "" {
+			return errSessionExpired
+		}
+		return errNotLoggedIn
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		// Try to extract error message from response body if it's JSON
 		var errorResponse map[string]interface{}
@@ -246,6 +320,12 @@ func ParseResponse(resp *http.Response, result interface{}) error {
 	return nil
 }
 
+// isLoginPath reports whether a 401 from path means bad credentials rather
+// than a rejected session.
+func isLoginPath(path string) bool {
+	return strings.HasSuffix(path, "/auth/login") || strings.HasSuffix(path, "/auth/login/mfa")
+}
+
 // FormatResponse formats the response as pretty JSON.
 func FormatResponse(data interface{}) (string, error) {
 	prettyJSON, err := json.MarshalIndent(data, "", "  ")
@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// completionCacheTTL is how long API lookups are reused between completions,
+// so pressing TAB repeatedly does not hit the API every time.
+const completionCacheTTL = 30 * time.Second
+
+// completionCacheEntry is a cached resource list.
+type completionCacheEntry struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Resources []namedResource `json:"resources"`
+}
+
+// completionCache maps API URL and resource kind to cached resources.
+type completionCache map[string]completionCacheEntry
+
+// completionFunc is the signature cobra uses for argument and flag completion.
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// Completion Implementation.
+
+// completeRangeArg completes the first argument with deployed ranges.
+func completeRangeArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeResources("range", rangeResources, toComplete)
+}
+
+// completeRangeArgs completes any number of deployed ranges.
+func completeRangeArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeResources("range", rangeResources, toComplete)
+}
+
+// completeBlueprintArg completes the first argument with blueprints of a kind.
+func completeBlueprintArg(kind string) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeResources(kind+"-blueprint", func() ([]namedResource, error) {
+			return blueprintResources(kind)
+		}, toComplete)
+	}
+}
+
+// completeWorkspaceArg completes the first argument with workspaces.
+func completeWorkspaceArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeResources("workspace", workspaceResources, toComplete)
+}
+
+// completeWorkspaceUserArgs completes a workspace and then one of its users.
+func completeWorkspaceUserArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return completeWorkspaceArg(cmd, args, toComplete)
+	case 1:
+		workspaceID, err := resolveWorkspaceID(args[0])
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeResources(fmt.Sprintf("workspace-%d-user", workspaceID), func() ([]namedResource, error) {
+			return workspaceUserResources(workspaceID)
+		}, toComplete)
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeWorkspaceBlueprintArgs completes a workspace and then a blueprint of
+// the kind given by --blueprint-type.
+func completeWorkspaceBlueprintArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return completeWorkspaceArg(cmd, args, toComplete)
+	case 1:
+		kind := blueprintTypeFlag(cmd)
+		return completeResources(kind+"-blueprint", func() ([]namedResource, error) {
+			return blueprintResources(kind)
+		}, toComplete)
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeRangeFlag completes a flag with deployed range IDs and names.
+func completeRangeFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeResources("range", rangeResources, toComplete)
+}
+
+// completeRangeBlueprintFlag completes a flag with range blueprint IDs and
+// names.
+func completeRangeBlueprintFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeResources("range-blueprint", func() ([]namedResource, error) {
+		return blueprintResources("range")
+	}, toComplete)
+}
+
+// completeBlueprintTypeFlag completes blueprint IDs and names of the kind
+// given by the command's --blueprint-type flag, defaulting to range
+// blueprints.
+func completeBlueprintTypeFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	kind := blueprintTypeFlag(cmd)
+	return completeResources(kind+"-blueprint", func() ([]namedResource, error) {
+		return blueprintResources(kind)
+	}, toComplete)
+}
+
+func blueprintTypeFlag(cmd *cobra.Command) string {
+	kind, _ := cmd.Flags().GetString("blueprint-type")
+	if !containsString(blueprintTypes, kind) {
+		return "range"
+	}
+	return kind
+}
+
+// completeWorkspaceFlag completes a flag with workspace IDs and names.
+func completeWorkspaceFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeResources("workspace", workspaceResources, toComplete)
+}
+
+// completeInstalledPluginArg completes the first argument with plugins
+// installed by the CLI.
+func completeInstalledPluginArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	index, err := loadPluginIndex()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []string
+	for name, entry := range index {
+		if strings.HasPrefix(name, toComplete) {
+			completions = append(completions, name+"\t"+entry.Version)
+		}
+	}
+	sort.Strings(completions)
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeValues completes a fixed set of values.
+func completeValues(values ...string) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var completions []string
+		for _, v := range values {
+			if strings.HasPrefix(v, toComplete) {
+				completions = append(completions, v)
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeResources offers IDs described by name while the user is typing a
+// number, and names described by ID otherwise.
+func completeResources(kind string, list func() ([]namedResource, error), toComplete string) ([]string, cobra.ShellCompDirective) {
+	resources, err := cachedResources(kind, list)
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("failed to list %ss: %s", kind, err), true)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	typingID := toComplete == "" || isDigits(toComplete)
+	var completions []string
+	for _, r := range resources {
+		id := strconv.Itoa(r.ID)
+		switch {
+		case typingID:
+			if strings.HasPrefix(id, toComplete) {
+				completions = append(completions, id+"\t"+r.Name)
+			}
+		case strings.HasPrefix(strings.ToLower(r.Name), strings.ToLower(toComplete)):
+			// Shells split completions on whitespace
+			if !strings.ContainsAny(r.Name, " \t") {
+				completions = append(completions, r.Name+"\tID "+id)
+			}
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// cachedResources returns a resource list from the completion cache, fetching
+// and caching it when missing or older than completionCacheTTL.
+func cachedResources(kind string, list func() ([]namedResource, error)) ([]namedResource, error) {
+	key := NewClient().BaseURL + " " + kind
+	cache := loadCompletionCache()
+
+	if entry, ok := cache[key]; ok && time.Since(entry.FetchedAt) < completionCacheTTL {
+		return entry.Resources, nil
+	}
+
+	resources, err := list()
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop stale entries so the cache does not grow without bound
+	for k, entry := range cache {
+		if time.Since(entry.FetchedAt) >= completionCacheTTL {
+			delete(cache, k)
+		}
+	}
+	cache[key] = completionCacheEntry{FetchedAt: time.Now(), Resources: resources}
+	if err := saveCompletionCache(cache); err != nil {
+		cobra.CompDebugln(fmt.Sprintf("failed to save completion cache: %s", err), true)
+	}
+	return resources, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func getCompletionCachePath() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "completion-cache.json"), nil
+}
+
+func loadCompletionCache() completionCache {
+	cache := completionCache{}
+
+	path, err := getCompletionCachePath()
+	if err != nil {
+		return cache
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	_ = json.Unmarshal(data, &cache)
+	return cache
+}
+
+func saveCompletionCache(cache completionCache) error {
+	path, err := getCompletionCachePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
@@ -14,6 +14,16 @@ type Configuration struct {
 	APIURL    string `json:"api_url"`
 	AuthToken string `json:"auth_token"`
 	EncKey    string `json:"enc_key"`
+
+	// RefreshToken renews the session when the API supports it
+	RefreshToken string `json:"refresh_token,omitempty"`
+
+	// APIKey is used instead of the session when set
+	APIKey string `json:"api_key,omitempty"`
+
+	// TokenURL and ClientID renew a session from a web login
+	TokenURL string `json:"token_url,omitempty"`
+	ClientID string `json:"client_id,omitempty"`
 }
 
 // Config Commands.
@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// confirmDestructive describes what a command is about to do and asks the
+// user to confirm it. It does not prompt when --yes or --dry-run is set, and
+// refuses to prompt when stdin is not a terminal so scripts fail instead of
+// hanging. Errors and aborts are printed; callers only check the result.
+func confirmDestructive(action string, details func() []string) bool {
+	if AssumeYes || DryRun {
+		return true
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Printf("Error: refusing to %s without confirmation because stdin is not a terminal; use --yes to confirm\n", action)
+		return false
+	}
+
+	fmt.Printf("You are about to %s:\n", action)
+	for _, line := range details() {
+		fmt.Printf("  %s\n", line)
+	}
+
+	ok, err := newPrompter().confirm("Continue?", false)
+	if err != nil {
+		fmt.Printf("Error reading confirmation: %s\n", err)
+		return false
+	}
+	if !ok {
+		fmt.Println("Aborted")
+	}
+	return ok
+}
+
+// Descriptions of resources shown before they are changed. Lookup failures
+// are shown in place of the details rather than blocking the prompt.
+func describeRange(id int) func() []string {
+	return func() []string {
+		r, err := fetchRange(id)
+		if err != nil {
+			return []string{fmt.Sprintf("Range %d (details unavailable: %s)", id, err)}
+		}
+		lines := []string{
+			fmt.Sprintf("Range:      %s (ID %d)", r.Name, r.ID),
+			fmt.Sprintf("State:      %s", r.State),
+			fmt.Sprintf("Blueprint:  %d", r.BlueprintID),
+			fmt.Sprintf("Provider:   %s %s", r.Provider, r.Region),
+		}
+		if !r.CreatedAt.IsZero() {
+			lines = append(lines, fmt.Sprintf("Created:    %s (%s ago)", r.CreatedAt.Local().Format(time.RFC1123), formatRemaining(time.Since(r.CreatedAt))))
+		}
+		return lines
+	}
+}
+
+func describeWorkspace(id int) func() []string {
+	return func() []string {
+		workspaces, err := fetchWorkspaces()
+		if err != nil {
+			return []string{fmt.Sprintf("Workspace %d (details unavailable: %s)", id, err)}
+		}
+		for _, w := range workspaces {
+			if w.ID != id {
+				continue
+			}
+			lines := []string{fmt.Sprintf("Workspace:  %s (ID %d)", w.Name, w.ID)}
+			if w.Description != "" {
+				lines = append(lines, fmt.Sprintf("Description: %s", w.Description))
+			}
+			if users, err := fetchWorkspaceUsers(id); err == nil {
+				lines = append(lines, fmt.Sprintf("Users:      %d", len(users)))
+			}
+			return append(lines, fmt.Sprintf("Created:    %s", w.CreatedAt.Local().Format(time.RFC1123)))
+		}
+		return []string{fmt.Sprintf("Workspace %d (not found)", id)}
+	}
+}
+
+func describeWorkspaceUser(workspaceID, userID int) func() []string {
+	return func() []string {
+		workspace := fmt.Sprintf("Workspace:  %d", workspaceID)
+		if workspaces, err := fetchWorkspaces(); err == nil {
+			for _, w := range workspaces {
+				if w.ID == workspaceID {
+					workspace = fmt.Sprintf("Workspace:  %s (ID %d)", w.Name, w.ID)
+				}
+			}
+		}
+
+		users, err := fetchWorkspaceUsers(workspaceID)
+		if err != nil {
+			return []string{fmt.Sprintf("User %d (details unavailable: %s)", userID, err), workspace}
+		}
+		for _, u := range users {
+			if u.ID == userID {
+				return []string{
+					fmt.Sprintf("User:       %s <%s> (ID %d)", u.Name, u.Email, u.ID),
+					fmt.Sprintf("Role:       %s", u.Role),
+					workspace,
+				}
+			}
+		}
+		return []string{fmt.Sprintf("User %d (not a member)", userID), workspace}
+	}
+}
+
+func describeBlueprint(kind string, id int) func() []string {
+	return func() []string {
+		client := NewClient()
+		resp, err := client.DoRequest("GET", fmt.Sprintf("/api/v1/blueprints/%ss/%d", kind, id), nil)
+		if err != nil {
+			return []string{fmt.Sprintf("%s blueprint %d (details unavailable: %s)", kind, id, err)}
+		}
+		defer func() {
+			err := resp.Body.Close()
+			if err != nil {
+				fmt.Printf("Error closing response body: %v\n", err)
+			}
+		}()
+
+		var blueprint struct {
+			Name        string `json:"name"`
+			Hostname    string `json:"hostname"`
+			Provider    string `json:"provider"`
+			CIDR        string `json:"cidr"`
+			OS          string `json:"os"`
+			Description string `json:"description"`
+		}
+		if err := ParseResponse(resp, &blueprint); err != nil {
+			return []string{fmt.Sprintf("%s blueprint %d (details unavailable: %s)", kind, id, err)}
+		}
+
+		name := blueprint.Name
+		if kind == "host" {
+			name = blueprint.Hostname
+		}
+		lines := []string{fmt.Sprintf("Blueprint:  %s (%s, ID %d)", name, kind, id)}
+		for _, field := range []struct{ label, value string }{
+			{"Provider:", blueprint.Provider},
+			{"CIDR:", blueprint.CIDR},
+			{"OS:", blueprint.OS},
+			{"Description:", blueprint.Description},
+		} {
+			if field.value != "" {
+				lines = append(lines, fmt.Sprintf("%-11s %s", field.label, field.value))
+			}
+		}
+		return lines
+	}
+}
+
+func describeAPIKey(id int) func() []string {
+	return func() []string {
+		keys, err := fetchAPIKeys()
+		if err != nil {
+			return []string{fmt.Sprintf("API key %d (details unavailable: %s)", id, err)}
+		}
+		for _, k := range keys {
+			if k.ID == id {
+				return []string{
+					fmt.Sprintf("API key:    %s (ID %d)", k.Name, k.ID),
+					fmt.Sprintf("Created:    %s", k.CreatedAt.Local().Format(time.RFC1123)),
+					fmt.Sprintf("Expires:    %s", formatKeyExpiry(k.ExpiresAt)),
+				}
+			}
+		}
+		return []string{fmt.Sprintf("API key %d (not found)", id)}
+	}
+}
+
+func describeAdminUser(id int) func() []string {
+	return func() []string {
+		u, err := fetchAdminUser(id)
+		if err != nil {
+			return []string{fmt.Sprintf("User %d (details unavailable: %s)", id, err)}
+		}
+		return []string{
+			fmt.Sprintf("User:       %s <%s> (ID %d)", u.Name, u.Email, u.ID),
+			fmt.Sprintf("Admin:      %s", yesNo(u.Admin)),
+			fmt.Sprintf("Status:     %s", userStatus(u)),
+		}
+	}
+}
+
+func describeSession(s UserSession) []string {
+	lines := []string{
+		fmt.Sprintf("Session:    %d", s.ID),
+		fmt.Sprintf("Device:     %s", sessionDevice(s)),
+		fmt.Sprintf("IP address: %s", valueOrNA(s.IPAddress)),
+		fmt.Sprintf("Created:    %s", s.CreatedAt.Local().Format(time.RFC1123)),
+	}
+	if s.LastUsedAt != nil {
+		lines = append(lines, fmt.Sprintf("Last used:  %s (%s ago)", s.LastUsedAt.Local().Format(time.RFC1123), formatRemaining(time.Since(*s.LastUsedAt))))
+	}
+	return lines
+}
+
+// printDryRunRequest shows a request that --dry-run kept from being sent.
+// Values of sensitive fields in the body are masked.
+func printDryRunRequest(method, requestURL, body string) {
+	fmt.Printf("DRY RUN: %s %s\n", method, requestURL)
+	if body != "" {
+		fmt.Printf("Body: %s\n", redactJSON(body))
+	}
+}
+
+func redactJSON(body string) string {
+	var data interface{}
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		return body
+	}
+	redactValue(data)
+	redacted, err := json.Marshal(data)
+	if err != nil {
+		return body
+	}
+	return string(redacted)
+}
+
+func redactValue(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			lower := strings.ToLower(key)
+			if strings.Contains(lower, "password") || strings.Contains(lower, "secret") || strings.Contains(lower, "token") {
+				v[key] = "********"
+				continue
+			}
+			redactValue(value)
+		}
+	case []interface{}:
+		for _, value := range v {
+			redactValue(value)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/skip2/go-qrcode"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// mfaAttempts is how many codes enrollment accepts before giving up.
+const mfaAttempts = 3
+
+var errMFARequired = errors.New("this account requires a second factor; log in with 'openlabs user login'")
+
+// MFA model structures.
+type MFAEnrollment struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type MFACode struct {
+	Code string `json:"code"`
+}
+
+type MFAChallenge struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code"`
+}
+
+type MFARecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// MFA Commands.
+var mfaCmd = &cobra.Command{
+	Use:   "mfa",
+	Short: "Manage multi-factor authentication",
+	Long:  "This command lets you protect your account with a time-based one-time password (TOTP) app.",
+}
+
+var enableMFACmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Enable multi-factor authentication",
+	Long: "This command enrolls an authenticator app. Scan the QR code (or enter the key by hand), then type " +
+		"the code the app shows to finish. The recovery codes printed at the end let you log in if you lose the app.",
+	Run: func(cmd *cobra.Command, args []string) {
+		noQR, _ := cmd.Flags().GetBool("no-qr")
+		recoveryOut, _ := cmd.Flags().GetString("recovery-codes-out")
+
+		err := enableMFA(!noQR, recoveryOut)
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+var disableMFACmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Disable multi-factor authentication",
+	Long:  "This command removes the authenticator app from your account. It asks for a current code or a recovery code.",
+	Run: func(cmd *cobra.Command, args []string) {
+		code, _ := cmd.Flags().GetString("code")
+
+		if !confirmDestructive("disable multi-factor authentication", func() []string {
+			return []string{"Your account will be protected by your password only."}
+		}) {
+			return
+		}
+
+		err := disableMFA(code)
+		if err != nil {
+			printError(err)
+		}
+	},
+}
+
+// MFA Implementation.
+func enableMFA(showQR bool, recoveryOut string) error {
+	if DryRun {
+		return fmt.Errorf("MFA enrollment cannot be used with --dry-run")
+	}
+
+	client := NewClient()
+	resp, err := client.DoRequest("POST", "/api/v1/users/me/mfa/totp", nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var enrollment MFAEnrollment
+	if err := ParseResponse(resp, &enrollment); err != nil {
+		return err
+	}
+	if enrollment.Secret == "" && enrollment.OTPAuthURI == "" {
+		return fmt.Errorf("the API did not return a TOTP secret")
+	}
+
+	fmt.Println("\n🔐 Add OpenLabs to your authenticator app")
+	if showQR && enrollment.OTPAuthURI != "" {
+		qr, err := qrcode.New(enrollment.OTPAuthURI, qrcode.Medium)
+		if err != nil {
+			fmt.Printf("\n⚠️  Could not render QR code: %s\n", err)
+		} else {
+			fmt.Println()
+			fmt.Print(qr.ToSmallString(false))
+		}
+	}
+	if enrollment.Secret != "" {
+		fmt.Printf("\nSetup key: %s\n", formatTOTPSecret(enrollment.Secret))
+	}
+	if enrollment.OTPAuthURI != "" {
+		fmt.Printf("URI:       %s\n", enrollment.OTPAuthURI)
+	}
+	fmt.Println()
+
+	p := newPrompter()
+	var codes MFARecoveryCodes
+	for attempt := 1; ; attempt++ {
+		code, err := p.ask("Code from your app", "", validateMFACode)
+		if err != nil {
+			return fmt.Errorf("failed to read code: %s", err)
+		}
+
+		codes, err = verifyMFA(code)
+		if err == nil {
+			break
+		}
+		fmt.Printf("  ❌ %s\n", err)
+		if attempt == mfaAttempts {
+			return fmt.Errorf("MFA was not enabled; run 'openlabs user mfa enable' to start over")
+		}
+	}
+
+	fmt.Println("\n✅ Multi-factor authentication enabled!")
+	if len(codes.RecoveryCodes) == 0 {
+		return nil
+	}
+
+	if recoveryOut != "" {
+		content := strings.Join(codes.RecoveryCodes, "\n") + "\n"
+		if err := os.WriteFile(recoveryOut, []byte(content), 0600); err != nil {
+			fmt.Printf("\n⚠️  Failed to write recovery codes to %s: %s\n", recoveryOut, err)
+		} else {
+			fmt.Printf("\nRecovery codes were written to %s. Keep them somewhere safe.\n", recoveryOut)
+			return nil
+		}
+	}
+
+	fmt.Println("\nRecovery codes (each can be used once if you lose your authenticator app):")
+	for _, code := range codes.RecoveryCodes {
+		fmt.Printf("  %s\n", code)
+	}
+	fmt.Println("\nStore them somewhere safe. They will not be shown again.")
+	return nil
+}
+
+func verifyMFA(code string) (MFARecoveryCodes, error) {
+	client := NewClient()
+	resp, err := client.DoRequest("POST", "/api/v1/users/me/mfa/totp/verify", MFACode{Code: code})
+	if err != nil {
+		return MFARecoveryCodes{}, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var codes MFARecoveryCodes
+	if err := ParseResponse(resp, &codes); err != nil {
+		return MFARecoveryCodes{}, err
+	}
+	return codes, nil
+}
+
+func disableMFA(code string) error {
+	code, err := mfaCode(code, "Code from your app or a recovery code")()
+	if err != nil {
+		return err
+	}
+
+	client := NewClient()
+	resp, err := client.DoRequest("POST", "/api/v1/users/me/mfa/disable", MFACode{Code: code})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	if err := ParseResponse(resp, nil); err != nil {
+		return err
+	}
+
+	fmt.Println("Multi-factor authentication disabled")
+	return nil
+}
+
+// completeMFAChallenge answers the second factor challenge issued by login.
+func completeMFAChallenge(mfaToken, code string) (authSession, error) {
+	client := NewClient()
+	client.AuthToken = ""
+	client.EncKey = ""
+	client.APIKey = ""
+
+	resp, err := client.DoRequest("POST", "/api/v1/auth/login/mfa", MFAChallenge{MFAToken: mfaToken, Code: code})
+	if err != nil {
+		return authSession{}, fmt.Errorf("login request failed: %w", err)
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	if err := ParseResponse(resp, &result); err != nil {
+		return authSession{}, fmt.Errorf("login failed: %s", err)
+	}
+	if !result.Success {
+		return authSession{}, fmt.Errorf("login failed: the API did not accept the code")
+	}
+
+	return sessionFromCookies(resp.Cookies())
+}
+
+// mfaCode returns a function that supplies a second factor code from the
+// given flag value, then OPENLABS_MFA_CODE, then a prompt when stdin is a
+// terminal. It is only called once the API asks for a code.
+func mfaCode(code, label string) func() (string, error) {
+	return func() (string, error) {
+		if code == "" {
+			code = strings.TrimSpace(os.Getenv("OPENLABS_MFA_CODE"))
+		}
+		if code != "" {
+			return code, nil
+		}
+
+		if !term.IsTerminal(int(syscall.Stdin)) {
+			return "", fmt.Errorf("a second factor is required; use --mfa-code or set OPENLABS_MFA_CODE")
+		}
+		answer, err := newPrompter().ask(label, "", func(s string) error {
+			if s == "" {
+				return fmt.Errorf("a code is required")
+			}
+			return nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to read code: %s", err)
+		}
+		return answer, nil
+	}
+}
+
+func validateMFACode(s string) error {
+	if len(s) != 6 || strings.Trim(s, "0123456789") != "" {
+		return fmt.Errorf("enter the 6-digit code shown in your app")
+	}
+	return nil
+}
+
+// formatTOTPSecret groups a base32 secret in fours so it is easier to type.
+func formatTOTPSecret(secret string) string {
+	var groups []string
+	for len(secret) > 4 {
+		groups = append(groups, secret[:4])
+		secret = secret[4:]
+	}
+	return strings.Join(append(groups, secret), " ")
+}
+
+func init() {
+	enableMFACmd.Flags().Bool("no-qr", false, "Do not print a QR code, only the setup key")
+	enableMFACmd.Flags().String("recovery-codes-out", "", "Write the recovery codes to this file instead of printing them")
+	disableMFACmd.Flags().String("code", "", "Current code or recovery code (prompted for when omitted)")
+
+	mfaCmd.AddCommand(enableMFACmd)
+	mfaCmd.AddCommand(disableMFACmd)
+
+	userCmd.AddCommand(mfaCmd)
+}
@@ -0,0 +1,354 @@
+package cmd
+
+import (
+	_ "embed"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"unicode"
+
+	"golang.org/x/term"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordData string
+
+// passwordAttempts is how many times a prompt asks for a new password before
+// giving up.
+const passwordAttempts = 3
+
+// PasswordPolicy is the minimum a new password must meet. The API may publish
+// its own policy; fields it leaves out keep the CLI's defaults.
+type PasswordPolicy struct {
+	MinLength        int     `json:"min_length"`
+	MinEntropyBits   float64 `json:"min_entropy_bits"`
+	RequireUppercase bool    `json:"require_uppercase"`
+	RequireLowercase bool    `json:"require_lowercase"`
+	RequireDigit     bool    `json:"require_digit"`
+	RequireSymbol    bool    `json:"require_symbol"`
+	DisallowCommon   bool    `json:"disallow_common"`
+}
+
+var defaultPasswordPolicy = PasswordPolicy{
+	MinLength:      8,
+	MinEntropyBits: 36,
+	DisallowCommon: true,
+}
+
+// passwordStrength is the estimate for one password.
+type passwordStrength struct {
+	EntropyBits float64
+	Score       int
+	Common      bool
+	Warnings    []string
+}
+
+var strengthLabels = []string{"very weak", "weak", "fair", "strong", "very strong"}
+
+var (
+	commonPasswordsOnce sync.Once
+	commonPasswords     map[string]bool
+
+	passwordPolicyOnce      sync.Once
+	passwordPolicy          PasswordPolicy
+	passwordPolicyPublished bool
+)
+
+// fetchPasswordPolicy returns the API's password policy, or the default one
+// when the API does not publish it, and whether the API published it. The
+// result is cached for the process.
+func fetchPasswordPolicy() (PasswordPolicy, bool) {
+	passwordPolicyOnce.Do(func() {
+		policy, err := requestPasswordPolicy()
+		if err != nil {
+			if Debug {
+				fmt.Printf("DEBUG: Using default password policy: %s\n", err)
+			}
+			passwordPolicy = defaultPasswordPolicy
+			return
+		}
+		passwordPolicy, passwordPolicyPublished = policy, true
+	})
+	return passwordPolicy, passwordPolicyPublished
+}
+
+// requestPasswordPolicy reads the API's password policy over the defaults.
+func requestPasswordPolicy() (PasswordPolicy, error) {
+	client := NewClient()
+	resp, err := client.DoRequest("GET", "/api/v1/auth/password-policy", nil)
+	if err != nil {
+		return PasswordPolicy{}, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	policy := defaultPasswordPolicy
+	if err := ParseResponse(resp, &policy); err != nil {
+		return PasswordPolicy{}, err
+	}
+	return policy, nil
+}
+
+// estimatePasswordStrength estimates how hard a password is to guess. The
+// estimate starts from the character classes used and discounts repeats,
+// sequences, common passwords, and personal details such as the user's name.
+func estimatePasswordStrength(password string, hints ...string) passwordStrength {
+	var s passwordStrength
+	if password == "" {
+		return s
+	}
+
+	pool := 0
+	lower, upper, digit, symbol, other := passwordClasses(password)
+	if lower {
+		pool += 26
+	}
+	if upper {
+		pool += 26
+	}
+	if digit {
+		pool += 10
+	}
+	if symbol {
+		pool += 33
+	}
+	if other {
+		pool += 100
+	}
+	bitsPerChar := math.Log2(float64(pool))
+
+	// Repeated and sequential characters add little
+	runes := []rune(password)
+	effective := 1.0
+	predictable := 0
+	for i := 1; i < len(runes); i++ {
+		step := runes[i] - runes[i-1]
+		if step >= -1 && step <= 1 {
+			effective += 0.25
+			predictable++
+		} else {
+			effective++
+		}
+	}
+	s.EntropyBits = effective * bitsPerChar
+	if predictable*2 >= len(runes) && len(runes) > 2 {
+		s.Warnings = append(s.Warnings, "avoid repeated or sequential characters like 'aaa' or '123'")
+	}
+
+	// Common passwords, with substitutions and a number or symbol tacked on
+	base := strings.TrimRightFunc(strings.ToLower(password), func(r rune) bool {
+		return unicode.IsDigit(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+	})
+	if isCommonPassword(unleet(strings.ToLower(password))) {
+		s.Common = true
+		s.EntropyBits = math.Min(s.EntropyBits, 10)
+		s.Warnings = append(s.Warnings, "this is one of the most commonly used passwords")
+	} else if len(base) >= 4 && isCommonPassword(unleet(base)) {
+		s.Common = true
+		suffix := len(runes) - len([]rune(base))
+		s.EntropyBits = math.Min(s.EntropyBits, 10+float64(suffix)*math.Log2(43))
+		s.Warnings = append(s.Warnings, "this is a common password with a few characters added")
+	}
+
+	// Personal details are the first thing an attacker tries. Longer parts go
+	// first so a part inside one already found, like "alex" in "alexander",
+	// is not discounted twice.
+	var parts []string
+	for _, hint := range hints {
+		parts = append(parts, strings.FieldsFunc(strings.ToLower(hint), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})...)
+	}
+	sort.SliceStable(parts, func(i, j int) bool { return len(parts[i]) > len(parts[j]) })
+
+	var personal []string
+	for _, part := range parts {
+		if len(part) < 3 || !strings.Contains(strings.ToLower(password), part) || containsSubstring(personal, part) {
+			continue
+		}
+		personal = append(personal, part)
+		s.EntropyBits = math.Max(0, s.EntropyBits-float64(len(part)-1)*bitsPerChar)
+	}
+	if len(personal) > 0 {
+		s.Warnings = append(s.Warnings, "avoid using your name or email in your password")
+	}
+
+	switch {
+	case s.EntropyBits < 28:
+		s.Score = 0
+	case s.EntropyBits < 36:
+		s.Score = 1
+	case s.EntropyBits < 60:
+		s.Score = 2
+	case s.EntropyBits < 80:
+		s.Score = 3
+	default:
+		s.Score = 4
+	}
+	return s
+}
+
+// checkPassword lists the ways a password falls short of the policy.
+func checkPassword(password string, policy PasswordPolicy, hints ...string) []string {
+	var problems []string
+	strength := estimatePasswordStrength(password, hints...)
+
+	if len([]rune(password)) < policy.MinLength {
+		problems = append(problems, fmt.Sprintf("must be at least %d characters long", policy.MinLength))
+	}
+	lower, upper, digit, symbol, _ := passwordClasses(password)
+	if policy.RequireLowercase && !lower {
+		problems = append(problems, "must contain a lowercase letter")
+	}
+	if policy.RequireUppercase && !upper {
+		problems = append(problems, "must contain an uppercase letter")
+	}
+	if policy.RequireDigit && !digit {
+		problems = append(problems, "must contain a digit")
+	}
+	if policy.RequireSymbol && !symbol {
+		problems = append(problems, "must contain a symbol")
+	}
+	if policy.DisallowCommon && strength.Common {
+		problems = append(problems, "must not be a common password")
+	}
+	if strength.EntropyBits < policy.MinEntropyBits {
+		problems = append(problems, fmt.Sprintf("is too easy to guess (%.0f bits, need %.0f)", strength.EntropyBits, policy.MinEntropyBits))
+	}
+	return problems
+}
+
+// validatePassword checks a password that was given non-interactively. When
+// the API does not publish a policy, the default one only prints warnings,
+// since the API may accept passwords it would reject.
+func validatePassword(password string, hints ...string) error {
+	policy, published := fetchPasswordPolicy()
+	problems := checkPassword(password, policy, hints...)
+	if len(problems) == 0 {
+		return nil
+	}
+	if !published {
+		fmt.Printf("⚠️  Password %s\n", strings.Join(problems, ", "))
+		return nil
+	}
+	return fmt.Errorf("password %s", strings.Join(problems, ", "))
+}
+
+// promptNewPassword asks for a new password until one meets the policy,
+// showing its strength after each attempt, and then asks to confirm it. When
+// the API does not publish a policy, problems are shown as warnings only.
+func promptNewPassword(label string, hints ...string) (string, error) {
+	policy, published := fetchPasswordPolicy()
+
+	for attempt := 1; ; attempt++ {
+		fmt.Printf("%s: ", label)
+		passwordBytes, err := term.ReadPassword(int(syscall.Stdin))
+		if err != nil {
+			return "", err
+		}
+		fmt.Println()
+		password := string(passwordBytes)
+
+		strength := estimatePasswordStrength(password, hints...)
+		fmt.Printf("Strength: %s\n", formatStrength(strength))
+		for _, warning := range strength.Warnings {
+			fmt.Printf("  ⚠️  %s\n", warning)
+		}
+
+		problems := checkPassword(password, policy, hints...)
+		if len(problems) > 0 && published {
+			for _, problem := range problems {
+				fmt.Printf("  ❌ Password %s\n", problem)
+			}
+			if attempt == passwordAttempts {
+				return "", fmt.Errorf("password does not meet the password policy")
+			}
+			fmt.Println()
+			continue
+		}
+		for _, problem := range problems {
+			fmt.Printf("  ⚠️  Password %s\n", problem)
+		}
+
+		fmt.Printf("Confirm %s: ", label)
+		confirmBytes, err := term.ReadPassword(int(syscall.Stdin))
+		if err != nil {
+			return "", err
+		}
+		fmt.Println()
+		if string(confirmBytes) != password {
+			return "", fmt.Errorf("passwords don't match")
+		}
+		return password, nil
+	}
+}
+
+// passwordHints returns the logged in user's name and email, if known, so a
+// new password can be checked for them.
+func passwordHints() []string {
+	user, err := fetchCurrentUser()
+	if err != nil {
+		return nil
+	}
+	return []string{user.Name, user.Email}
+}
+
+// formatStrength renders a strength meter such as "▰▰▰▱▱ strong (64 bits)".
+func formatStrength(s passwordStrength) string {
+	filled := s.Score + 1
+	return fmt.Sprintf("%s%s %s (%.0f bits)", strings.Repeat("▰", filled), strings.Repeat("▱", len(strengthLabels)-filled),
+		strengthLabels[s.Score], s.EntropyBits)
+}
+
+func passwordClasses(password string) (lower, upper, digit, symbol, other bool) {
+	for _, r := range password {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII && (unicode.IsPunct(r) || unicode.IsSymbol(r) || r == ' '):
+			symbol = true
+		default:
+			other = true
+		}
+	}
+	return
+}
+
+// unleet undoes common character substitutions such as "p@ssw0rd".
+func unleet(s string) string {
+	return strings.NewReplacer("@", "a", "4", "a", "3", "e", "1", "i", "!", "i", "0", "o", "$", "s", "5", "s", "7", "t").Replace(s)
+}
+
+// containsSubstring reports whether any string in the list contains sub.
+func containsSubstring(list []string, sub string) bool {
+	for _, s := range list {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+func isCommonPassword(s string) bool {
+	commonPasswordsOnce.Do(func() {
+		commonPasswords = map[string]bool{}
+		for _, line := range strings.Split(commonPasswordData, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				commonPasswords[line] = true
+				commonPasswords[unleet(line)] = true
+			}
+		}
+	})
+	return commonPasswords[s]
+}
@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestUnleet(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"password", "password"},
+		{"p@ssw0rd", "password"},
+		{"p4$$w0rd", "password"},
+		{"1etm3in", "ietmein"},
+		{"l3tm!n", "letmin"},
+		{"7ru5t", "trust"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := unleet(tt.in); got != tt.want {
+			t.Errorf("unleet(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEstimatePasswordStrength(t *testing.T) {
+	tests := []struct {
+		name        string
+		password    string
+		hints       []string
+		wantCommon  bool
+		maxBits     float64
+		minScore    int
+		wantWarning string
+	}{
+		{name: "empty", password: "", maxBits: 0},
+		{name: "common", password: "password", wantCommon: true, maxBits: 10, wantWarning: "most commonly used"},
+		{name: "common uppercase", password: "Dragon", wantCommon: true, maxBits: 10},
+		{name: "leet", password: "p@ssw0rd", wantCommon: true, maxBits: 10},
+		{name: "suffixed", password: "baseball2024!", wantCommon: true, maxBits: 38, wantWarning: "common password with a few characters added"},
+		{name: "leet and suffixed", password: "p@ssw0rd99", wantCommon: true, maxBits: 22},
+		{name: "repeated", password: "aaaaaaaaaa", maxBits: 16, wantWarning: "repeated or sequential"},
+		{name: "sequential", password: "abcdefgh", maxBits: 15, wantWarning: "repeated or sequential"},
+		{name: "random", password: "tR7#qL9!vZ2@mK4$", minScore: 4},
+		{name: "passphrase", password: "purple tractor anchors gently", minScore: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := estimatePasswordStrength(tt.password, tt.hints...)
+
+			if got.Common != tt.wantCommon {
+				t.Errorf("Common = %v, want %v", got.Common, tt.wantCommon)
+			}
+			if tt.maxBits > 0 || tt.password == "" {
+				if got.EntropyBits > tt.maxBits {
+					t.Errorf("EntropyBits = %.1f, want at most %.1f", got.EntropyBits, tt.maxBits)
+				}
+			}
+			if got.Score < tt.minScore {
+				t.Errorf("Score = %d (%.1f bits), want at least %d", got.Score, got.EntropyBits, tt.minScore)
+			}
+			if tt.wantWarning != "" && !containsSubstring(got.Warnings, tt.wantWarning) {
+				t.Errorf("Warnings = %q, want one containing %q", got.Warnings, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestEstimatePasswordStrengthPersonalHints(t *testing.T) {
+	const password = "Alexander#Harbor81"
+	without := estimatePasswordStrength(password)
+	with := estimatePasswordStrength(password, "Alexander Harbor", "alex.harbor@example.com")
+
+	if with.EntropyBits >= without.EntropyBits {
+		t.Errorf("hints did not lower the estimate: %.1f bits with, %.1f without", with.EntropyBits, without.EntropyBits)
+	}
+	if !containsSubstring(with.Warnings, "your name or email") {
+		t.Errorf("Warnings = %q, want a personal details warning", with.Warnings)
+	}
+	if containsSubstring(without.Warnings, "your name or email") {
+		t.Errorf("Warnings without hints = %q", without.Warnings)
+	}
+
+	// A name given in both hints, or found inside a longer one, only counts once
+	once := estimatePasswordStrength(password, "Alexander Harbor")
+	if with.EntropyBits != once.EntropyBits {
+		t.Errorf("repeated hint parts were discounted twice: %.1f bits, want %.1f", with.EntropyBits, once.EntropyBits)
+	}
+
+	// Parts shorter than three characters are too common to discount
+	short := estimatePasswordStrength(password, "Al")
+	if short.EntropyBits != without.EntropyBits {
+		t.Errorf("short hint changed the estimate: %.1f bits, want %.1f", short.EntropyBits, without.EntropyBits)
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	strict := PasswordPolicy{
+		MinLength:        12,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		policy   PasswordPolicy
+		hints    []string
+		want     []string
+		// wantWeak is set when the only problem should be the entropy check
+		wantWeak bool
+	}{
+		{
+			name:     "strong password meets default policy",
+			password: "tR7#qL9!vZ2@mK4$",
+			policy:   defaultPasswordPolicy,
+		},
+		{
+			name:     "common password",
+			password: "password",
+			policy:   defaultPasswordPolicy,
+			want:     []string{"must not be a common password", "is too easy to guess (10 bits, need 36)"},
+		},
+		{
+			name:     "common password allowed by policy",
+			password: "password",
+			policy:   PasswordPolicy{MinLength: 8},
+		},
+		{
+			name:     "too short",
+			password: "xQ7#",
+			policy:   PasswordPolicy{MinLength: 8},
+			want:     []string{"must be at least 8 characters long"},
+		},
+		{
+			name:     "missing character classes",
+			password: "lowercaseonlyhere",
+			policy:   strict,
+			want:     []string{"must contain an uppercase letter", "must contain a digit", "must contain a symbol"},
+		},
+		{
+			name:     "personal details",
+			password: "Alexander#Harbor81",
+			policy:   PasswordPolicy{MinEntropyBits: 60},
+			hints:    []string{"Alexander Harbor"},
+			wantWeak: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkPassword(tt.password, tt.policy, tt.hints...)
+
+			if tt.wantWeak {
+				if len(got) != 1 || !strings.HasPrefix(got[0], "is too easy to guess") {
+					t.Errorf("checkPassword() = %q, want only the entropy problem", got)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checkPassword() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestPasswordPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    PasswordPolicy
+		wantErr bool
+	}{
+		{
+			name:   "partial policy keeps defaults",
+			status: http.StatusOK,
+			body:   `{"min_length": 12, "require_symbol": true}`,
+			want:   PasswordPolicy{MinLength: 12, MinEntropyBits: 36, RequireSymbol: true, DisallowCommon: true},
+		},
+		{
+			name:   "policy overrides defaults",
+			status: http.StatusOK,
+			body:   `{"min_length": 6, "min_entropy_bits": 0, "disallow_common": false}`,
+			want:   PasswordPolicy{MinLength: 6},
+		},
+		{
+			name:    "not published",
+			status:  http.StatusNotFound,
+			body:    `{"detail": "Not Found"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newTestAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/auth/password-policy" {
+					http.NotFound(w, r)
+					return
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+
+			got, err := requestPasswordPolicy()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("requestPasswordPolicy() = %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("requestPasswordPolicy() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("requestPasswordPolicy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePasswordUsesPublishedPolicyOnly(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantErr bool
+	}{
+		{
+			name:    "default policy only warns",
+			handler: http.NotFound,
+		},
+		{
+			name: "published policy blocks",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"min_length": 8}`))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetPasswordPolicy(t)
+			newTestAPI(t, tt.handler)
+
+			err := validatePassword("password")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validatePassword() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// resetPasswordPolicy clears the cached policy before and after a test.
+func resetPasswordPolicy(t *testing.T) {
+	t.Helper()
+	reset := func() {
+		passwordPolicyOnce = sync.Once{}
+		passwordPolicy, passwordPolicyPublished = PasswordPolicy{}, false
+	}
+	reset()
+	t.Cleanup(reset)
+}
@@ -1,20 +1,315 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
+// pluginPrefix is the executable name prefix that marks an OpenLabs plugin.
+const pluginPrefix = "openlabs-"
+
+// pluginVersionTimeout bounds how long a plugin may take to report its version.
+const pluginVersionTimeout = 3 * time.Second
+
+// Plugin is an external executable that extends the CLI.
+type Plugin struct {
+	Name string
+	Path string
+}
+
 var pluginsCmd = &cobra.Command{
 	Use:   "plugins",
 	Short: "View and deploy plugins",
-	Long:  "This command will let view plugins and deploy them to your range.",
+	Long: "This command will let you view plugins and deploy them to your range.\n\n" +
+		"Any executable named openlabs-<name> in ~/.openlabs/plugins or on your PATH can be run as 'openlabs <name>'. " +
+		"Plugins receive the API URL, auth token, encryption key, and API key through the OPENLABS_API_URL, " +
+		"OPENLABS_TOKEN, OPENLABS_ENC_KEY, and OPENLABS_API_KEY environment variables.",
+}
+
+var listPluginsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List installed plugins",
+	Long:  "This command will list the plugins found in ~/.openlabs/plugins and on your PATH along with their versions.",
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Placeholder")
+		err := listPlugins()
+		if err != nil {
+			fmt.Println(err)
+		}
 	},
 }
 
+// Plugins Implementation.
+func listPlugins() error {
+	plugins := discoverPlugins()
+
+	// Installed packages know their version and may not ship a command at all
+	index, err := loadPluginIndex()
+	if err != nil {
+		fmt.Printf("Warning: %s\n", err)
+	}
+	var packagesOnly []InstalledPlugin
+	for _, entry := range index {
+		if entry.Executable == "" {
+			packagesOnly = append(packagesOnly, entry)
+		}
+	}
+	sort.Slice(packagesOnly, func(i, j int) bool { return packagesOnly[i].Name < packagesOnly[j].Name })
+
+	if len(plugins) == 0 && len(packagesOnly) == 0 {
+		fmt.Println("No plugins found")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Version", "Path"})
+	for _, p := range plugins {
+		name := p.Name
+		if isBuiltinCommand(p.Name) {
+			name += " (shadowed by built-in command)"
+		}
+
+		var pluginVer string
+		if entry, ok := index[p.Name]; ok && entry.Executable == p.Path {
+			pluginVer = entry.Version
+		} else {
+			pluginVer = pluginVersion(p)
+		}
+		table.Append([]string{name, pluginVer, p.Path})
+	}
+	for _, entry := range packagesOnly {
+		table.Append([]string{entry.Name + " (package)", entry.Version, entry.PackageDir})
+	}
+	table.Render()
+	return nil
+}
+
+func getPluginsDir() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "plugins"), nil
+}
+
+// pluginSearchPath returns the directories searched for plugins, in order of precedence.
+func pluginSearchPath() []string {
+	var dirs []string
+	if pluginsDir, err := getPluginsDir(); err == nil {
+		dirs = append(dirs, pluginsDir)
+	}
+	return append(dirs, filepath.SplitList(os.Getenv("PATH"))...)
+}
+
+// discoverPlugins finds all plugins, keeping only the first plugin of each name.
+func discoverPlugins() []Plugin {
+	seen := map[string]bool{}
+	var plugins []Plugin
+
+	for _, dir := range pluginSearchPath() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok || seen[name] || entry.IsDir() {
+				continue
+			}
+
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+
+			seen[name] = true
+			plugins = append(plugins, Plugin{Name: name, Path: path})
+		}
+	}
+
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+// findPlugin looks up a single plugin by name.
+func findPlugin(name string) (Plugin, bool) {
+	for _, p := range discoverPlugins() {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Plugin{}, false
+}
+
+// pluginName extracts the plugin name from an executable file name.
+func pluginName(fileName string) (string, bool) {
+	if !strings.HasPrefix(fileName, pluginPrefix) {
+		return "", false
+	}
+
+	name := strings.TrimPrefix(fileName, pluginPrefix)
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".exe" && ext != ".bat" && ext != ".cmd" {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+
+	return name, name != ""
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	return info.Mode().Perm()&0111 != 0
+}
+
+// isBuiltinCommand reports whether name is handled by the CLI itself.
+func isBuiltinCommand(name string) bool {
+	switch name {
+	case "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return true
+	}
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// pluginVersion asks a plugin for its version with --version.
+func pluginVersion(p Plugin) string {
+	ctx, cancel := context.WithTimeout(context.Background(), pluginVersionTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, p.Path, "--version").Output()
+	if err != nil {
+		return "unknown"
+	}
+
+	firstLine := strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+	if firstLine == "" {
+		return "unknown"
+	}
+	return firstLine
+}
+
+// pluginEnv returns the environment passed to plugins, carrying the resolved
+// API URL and credentials.
+func pluginEnv() []string {
+	client := NewClient()
+
+	env := append(os.Environ(),
+		"OPENLABS_API_URL="+client.BaseURL,
+		"OPENLABS_TOKEN="+client.AuthToken,
+		"OPENLABS_ENC_KEY="+client.EncKey,
+		apiKeyEnv+"="+client.APIKey,
+		"OPENLABS_CLI_VERSION="+version,
+	)
+	if configPath, err := getConfigPath(); err == nil {
+		env = append(env, "OPENLABS_CONFIG="+configPath)
+	}
+	return env
+}
+
+// runPlugin runs a plugin attached to the terminal and returns its exit code.
+func runPlugin(p Plugin, args []string) int {
+	cmd := exec.Command(p.Path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = pluginEnv()
+
+	if Debug {
+		fmt.Printf("DEBUG: Running plugin %s from %s\n", p.Name, p.Path)
+	}
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode()
+		}
+		fmt.Printf("Error running plugin %s: %s\n", p.Name, err)
+		return 1
+	}
+	return 0
+}
+
+// dispatchPlugin runs the plugin named by the first argument when it is not a
+// built-in command. Global flags such as --api-url may come before the plugin
+// name; they are applied before the plugin's environment is built. It reports
+// whether a plugin was run.
+func dispatchPlugin(args []string) (int, bool) {
+	flags := rootCmd.PersistentFlags()
+
+	var names, values []string
+	i := 0
+	for ; i < len(args) && strings.HasPrefix(args[i], "-"); i++ {
+		if args[i] == "--" || args[i] == "-" {
+			return 0, false
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(args[i], "-"), "=")
+		lookup := flags.ShorthandLookup
+		if strings.HasPrefix(args[i], "--") {
+			lookup = flags.Lookup
+		} else if len(name) != 1 {
+			return 0, false
+		}
+		f := lookup(name)
+		if f == nil {
+			// Leave unknown flags for cobra to report
+			return 0, false
+		}
+		if !hasValue {
+			if f.NoOptDefVal != "" {
+				value = f.NoOptDefVal
+			} else if i+1 < len(args) {
+				i++
+				value = args[i]
+			} else {
+				return 0, false
+			}
+		}
+		names = append(names, f.Name)
+		values = append(values, value)
+	}
+
+	if i >= len(args) || isBuiltinCommand(args[i]) {
+		return 0, false
+	}
+	p, ok := findPlugin(args[i])
+	if !ok {
+		return 0, false
+	}
+
+	for j, name := range names {
+		if err := flags.Set(name, values[j]); err != nil {
+			fmt.Printf("Error: invalid argument %q for --%s: %s\n", values[j], name, err)
+			return 1, true
+		}
+	}
+	return runPlugin(p, args[i+1:]), true
+}
+
 func init() {
+	pluginsCmd.AddCommand(listPluginsCmd)
+
 	rootCmd.AddCommand(pluginsCmd)
 }
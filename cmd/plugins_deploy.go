@@ -0,0 +1,542 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// pluginRemoteDir is where plugin payloads are unpacked on range hosts.
+const pluginRemoteDir = "/opt/openlabs/plugins"
+
+// pluginDeployOptions control how a plugin is rolled out to range hosts.
+type pluginDeployOptions struct {
+	Hosts       string
+	SSHUser     string
+	JumpboxUser string
+	Parallel    int
+	Timeout     time.Duration
+	Sudo        bool
+}
+
+// hostDeployResult is the outcome of deploying a plugin to one host.
+type hostDeployResult struct {
+	Host     DeployedRangeHost
+	Status   string
+	Duration time.Duration
+	Detail   string
+}
+
+var deployPluginCmd = &cobra.Command{
+	Use:   "deploy [plugin-name]",
+	Short: "Deploy a plugin to hosts in a range",
+	Long: "This command copies an installed plugin's payload to the selected hosts of a deployed range over SSH, " +
+		"through the range jumpbox, and runs its install script.\n\n" +
+		"Select hosts with --hosts using tag=<tag>, hostname=<glob>, os=<os>, or all. " +
+		"Separate several values with commas, e.g. --hosts tag=web,db.",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		rangeArg, _ := cmd.Flags().GetString("range")
+		if rangeArg == "" {
+			fmt.Println("Error: --range is required")
+			return
+		}
+		rangeID, err := resolveRangeID(rangeArg)
+		if err != nil {
+			fmt.Printf("Error: %s\n", err)
+			return
+		}
+
+		opts := pluginDeployOptions{}
+		opts.Hosts, _ = cmd.Flags().GetString("hosts")
+		opts.SSHUser, _ = cmd.Flags().GetString("ssh-user")
+		opts.JumpboxUser, _ = cmd.Flags().GetString("jumpbox-user")
+		opts.Parallel, _ = cmd.Flags().GetInt("parallel")
+		opts.Timeout, _ = cmd.Flags().GetDuration("timeout")
+		opts.Sudo, _ = cmd.Flags().GetBool("sudo")
+
+		err = deployPlugin(args[0], rangeID, opts)
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+// Plugin Deployment Implementation.
+func deployPlugin(name string, rangeID int, opts pluginDeployOptions) error {
+	if opts.Parallel < 1 {
+		return fmt.Errorf("--parallel must be at least 1")
+	}
+	if opts.Timeout <= 0 {
+		return fmt.Errorf("--timeout must be positive")
+	}
+
+	index, err := loadPluginIndex()
+	if err != nil {
+		return err
+	}
+	entry, ok := index[name]
+	if !ok {
+		return fmt.Errorf("plugin %s is not installed; install it with 'openlabs plugins install' first", name)
+	}
+
+	manifest, err := readPluginManifest(entry.PackageDir)
+	if err != nil {
+		return err
+	}
+	if manifest.Deploy == nil {
+		return fmt.Errorf("plugin %s %s has nothing to deploy to range hosts", name, manifest.Version)
+	}
+
+	payload, err := packPluginPayload(entry.PackageDir, manifest.Deploy)
+	if err != nil {
+		return err
+	}
+
+	deployedRange, err := fetchRange(rangeID)
+	if err != nil {
+		return fmt.Errorf("failed to get range %d: %s", rangeID, err)
+	}
+	if deployedRange.JumpboxPublicIP == "" {
+		return fmt.Errorf("range %d has no jumpbox address (state: %s)", rangeID, deployedRange.State)
+	}
+
+	hosts, err := selectRangeHosts(deployedRange, opts.Hosts)
+	if err != nil {
+		return err
+	}
+	if len(hosts) == 0 {
+		return fmt.Errorf("no hosts in range %d match %q", rangeID, opts.Hosts)
+	}
+
+	if DryRun {
+		printPluginDeployPlan(manifest, deployedRange, hosts, opts)
+		return nil
+	}
+
+	key, err := fetchRangeKey(rangeID)
+	if err != nil {
+		return fmt.Errorf("failed to get SSH key for range %d: %s", rangeID, err)
+	}
+	keyPath, cleanup, err := writeTempKey(key)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	fmt.Printf("Deploying plugin %s %s to %d host(s) in range %s...\n", name, manifest.Version, len(hosts), deployedRange.Name)
+
+	results := make([]hostDeployResult, len(hosts))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, opts.Parallel)
+
+	for i, host := range hosts {
+		wg.Add(1)
+		go func(i int, host DeployedRangeHost) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = deployPluginToHost(host, manifest, payload, deployedRange.JumpboxPublicIP, keyPath, opts)
+			fmt.Printf("  %s %s\n", statusIcon(results[i].Status), host.Hostname)
+		}(i, host)
+	}
+	wg.Wait()
+
+	return printDeployResults(results)
+}
+
+// deployPluginToHost streams the payload to a host and runs the install script.
+func deployPluginToHost(host DeployedRangeHost, manifest PluginManifest, payload []byte, jumpboxIP, keyPath string, opts pluginDeployOptions) hostDeployResult {
+	result := hostDeployResult{Host: host}
+
+	if reason := pluginHostUnsupported(host, manifest.Deploy); reason != "" {
+		result.Status = "skipped"
+		result.Detail = reason
+		return result
+	}
+	if host.IPAddress == "" {
+		result.Status = "failed"
+		result.Detail = "host has no IP address"
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+
+	args := sshArgs(keyPath, jumpboxIP, opts.JumpboxUser)
+	args = append(args, pluginSSHUser(host, opts)+"@"+host.IPAddress, pluginInstallCommand(manifest, opts))
+
+	if Debug {
+		fmt.Printf("DEBUG: ssh %s\n", strings.Join(args, " "))
+	}
+
+	cmd := exec.CommandContext(ctx, "ssh", args...)
+	cmd.Stdin = bytes.NewReader(payload)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	start := time.Now()
+	err := cmd.Run()
+	result.Duration = time.Since(start).Round(100 * time.Millisecond)
+
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		result.Status = "failed"
+		result.Detail = fmt.Sprintf("timed out after %s", opts.Timeout)
+	case err != nil:
+		result.Status = "failed"
+		result.Detail = lastLine(output.String())
+		if result.Detail == "" {
+			result.Detail = err.Error()
+		}
+	default:
+		result.Status = "deployed"
+		result.Detail = lastLine(output.String())
+	}
+
+	return result
+}
+
+// printPluginDeployPlan shows which hosts a dry run would deploy to and the
+// command each would run.
+func printPluginDeployPlan(manifest PluginManifest, deployedRange DeployedRange, hosts []DeployedRangeHost, opts pluginDeployOptions) {
+	fmt.Printf("DRY RUN: would deploy plugin %s %s to %d host(s) in range %s through jumpbox %s\n",
+		manifest.Name, manifest.Version, len(hosts), deployedRange.Name, deployedRange.JumpboxPublicIP)
+	for _, host := range hosts {
+		switch reason := pluginHostUnsupported(host, manifest.Deploy); {
+		case reason != "":
+			fmt.Printf("  %s (skipped: %s)\n", host.Hostname, reason)
+		case host.IPAddress == "":
+			fmt.Printf("  %s (skipped: host has no IP address)\n", host.Hostname)
+		default:
+			fmt.Printf("  %s: %s@%s\n", host.Hostname, pluginSSHUser(host, opts), host.IPAddress)
+		}
+	}
+	fmt.Printf("Remote command: %s\n", pluginInstallCommand(manifest, opts))
+}
+
+// pluginSSHUser returns the user to log in to a host as.
+func pluginSSHUser(host DeployedRangeHost, opts pluginDeployOptions) string {
+	if opts.SSHUser != "" {
+		return opts.SSHUser
+	}
+	return defaultSSHUser(host.OS)
+}
+
+// pluginInstallCommand returns the remote command that unpacks the payload
+// from stdin and runs the install script.
+func pluginInstallCommand(manifest PluginManifest, opts pluginDeployOptions) string {
+	remoteDir := path.Join(pluginRemoteDir, manifest.Name)
+	install := "sh ./" + shellQuote(path.Clean(manifest.Deploy.Install))
+	if opts.Sudo {
+		install = "sudo " + install
+	}
+	// Unpack next to the previous version and swap it in only once the copy is complete
+	steps := []string{"set -e"}
+	if opts.Sudo {
+		steps = append(steps,
+			"sudo mkdir -p "+shellQuote(pluginRemoteDir),
+			"sudo chown \"$(id -u):$(id -g)\" "+shellQuote(pluginRemoteDir))
+	}
+	quotedDir, quotedTmp := shellQuote(remoteDir), shellQuote(remoteDir+".tmp")
+	steps = append(steps,
+		"rm -rf "+quotedTmp,
+		"mkdir -p "+quotedTmp,
+		"tar -xzf - -C "+quotedTmp,
+		"rm -rf "+quotedDir,
+		"mv "+quotedTmp+" "+quotedDir,
+		"cd "+quotedDir,
+		install)
+	return strings.Join(steps, "; ")
+}
+
+// selectRangeHosts returns the hosts of a range matching a selector such as
+// tag=web, hostname=web-*, os=ubuntu_22, or all.
+func selectRangeHosts(r DeployedRange, selector string) ([]DeployedRangeHost, error) {
+	selector = strings.TrimSpace(selector)
+	field, value, found := strings.Cut(selector, "=")
+	if !found && selector != "all" {
+		return nil, fmt.Errorf("invalid host selector %q; use tag=<tag>, hostname=<glob>, os=<os>, or all", selector)
+	}
+
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	if found && len(values) == 0 {
+		return nil, fmt.Errorf("host selector %q has no value", selector)
+	}
+
+	var match func(h DeployedRangeHost) bool
+	switch {
+	case selector == "all":
+		match = func(h DeployedRangeHost) bool { return true }
+	case field == "tag":
+		match = func(h DeployedRangeHost) bool {
+			for _, v := range values {
+				if containsString(h.Tags, v) {
+					return true
+				}
+			}
+			return false
+		}
+	case field == "hostname":
+		for _, v := range values {
+			if _, err := path.Match(v, ""); err != nil {
+				return nil, fmt.Errorf("invalid hostname pattern %q: %s", v, err)
+			}
+		}
+		match = func(h DeployedRangeHost) bool {
+			for _, v := range values {
+				if ok, _ := path.Match(v, h.Hostname); ok {
+					return true
+				}
+			}
+			return false
+		}
+	case field == "os":
+		match = func(h DeployedRangeHost) bool { return containsString(values, h.OS) }
+	default:
+		return nil, fmt.Errorf("unknown host selector %q; use tag, hostname, os, or all", field)
+	}
+
+	var hosts []DeployedRangeHost
+	for _, vpc := range r.VPCs {
+		for _, subnet := range vpc.Subnets {
+			for _, h := range subnet.Hosts {
+				if match(h) {
+					hosts = append(hosts, h)
+				}
+			}
+		}
+	}
+	return hosts, nil
+}
+
+// pluginHostUnsupported explains why a plugin cannot be deployed to a host,
+// or returns an empty string if it can.
+func pluginHostUnsupported(host DeployedRangeHost, deploy *PluginDeploy) string {
+	if strings.HasPrefix(host.OS, "windows") {
+		return "windows hosts are not supported"
+	}
+	if len(deploy.OS) > 0 && !containsString(deploy.OS, host.OS) {
+		return fmt.Sprintf("plugin does not support %s", host.OS)
+	}
+	return ""
+}
+
+// defaultSSHUser returns the default login user of the images used for each OS.
+func defaultSSHUser(hostOS string) string {
+	switch {
+	case strings.HasPrefix(hostOS, "ubuntu"):
+		return "ubuntu"
+	case strings.HasPrefix(hostOS, "debian"):
+		return "admin"
+	case strings.HasPrefix(hostOS, "suse"):
+		return "ec2-user"
+	case hostOS == "kali":
+		return "kali"
+	default:
+		return "root"
+	}
+}
+
+// sshArgs returns the ssh options for reaching range hosts through the jumpbox.
+// Range hosts are recreated on every deploy, so host keys are not recorded.
+func sshArgs(keyPath, jumpboxIP, jumpboxUser string) []string {
+	common := []string{
+		"-i", keyPath,
+		"-o", "IdentitiesOnly=yes",
+		"-o", "StrictHostKeyChecking=no",
+		"-o", "UserKnownHostsFile=/dev/null",
+		"-o", "LogLevel=ERROR",
+		"-o", "BatchMode=yes",
+		"-o", "ConnectTimeout=15",
+	}
+
+	proxy := fmt.Sprintf("ssh %s -W %%h:%%p %s", strings.Join(quoteArgs(common), " "), shellQuote(jumpboxUser+"@"+jumpboxIP))
+	return append(common, "-o", "ProxyCommand="+proxy)
+}
+
+// packPluginPayload builds an in-memory tar.gz of the plugin's deploy payload.
+func packPluginPayload(packageDir string, deploy *PluginDeploy) ([]byte, error) {
+	if deploy.Install == "" {
+		return nil, fmt.Errorf("plugin manifest does not name an install script")
+	}
+
+	payloadDir, err := safeJoin(packageDir, deploy.Payload)
+	if err != nil {
+		return nil, err
+	}
+	installPath, err := safeJoin(payloadDir, deploy.Install)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(installPath); err != nil {
+		return nil, fmt.Errorf("plugin install script %s not found in package", deploy.Install)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	err = filepath.Walk(payloadDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(payloadDir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		if !info.Mode().IsRegular() && !info.IsDir() {
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(tw, f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack plugin payload: %s", err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeTempKey writes a private key to a file only readable by the current user.
+func writeTempKey(key string) (string, func(), error) {
+	f, err := os.CreateTemp("", "openlabs-range-key-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to write range key: %s", err)
+	}
+	cleanup := func() { _ = os.Remove(f.Name()) }
+
+	if !strings.HasSuffix(key, "\n") {
+		key += "\n"
+	}
+	if err := f.Chmod(0600); err != nil && !os.IsPermission(err) {
+		_ = f.Close()
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write range key: %s", err)
+	}
+	if _, err := f.WriteString(key); err != nil {
+		_ = f.Close()
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write range key: %s", err)
+	}
+	if err := f.Close(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write range key: %s", err)
+	}
+	return f.Name(), cleanup, nil
+}
+
+func printDeployResults(results []hostDeployResult) error {
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Host.Hostname < results[j].Host.Hostname })
+
+	counts := map[string]int{}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Host", "IP", "Status", "Duration", "Detail"})
+	for _, r := range results {
+		counts[r.Status]++
+		duration := ""
+		if r.Status != "skipped" {
+			duration = r.Duration.String()
+		}
+		table.Append([]string{r.Host.Hostname, r.Host.IPAddress, r.Status, duration, r.Detail})
+	}
+	table.Render()
+
+	fmt.Printf("%d deployed, %d skipped, %d failed\n", counts["deployed"], counts["skipped"], counts["failed"])
+	if counts["failed"] > 0 {
+		return fmt.Errorf("plugin deployment failed on %d host(s)", counts["failed"])
+	}
+	return nil
+}
+
+func statusIcon(status string) string {
+	switch status {
+	case "deployed":
+		return "✅"
+	case "skipped":
+		return "⏭️ "
+	default:
+		return "❌"
+	}
+}
+
+// lastLine returns the last non-empty line of command output.
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// shellQuote quotes a string for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func quoteArgs(args []string) []string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = shellQuote(a)
+	}
+	return quoted
+}
+
+func init() {
+	deployPluginCmd.Flags().String("range", "", "ID or name of the deployed range")
+	deployPluginCmd.Flags().String("hosts", "all", "Hosts to deploy to: tag=<tag>, hostname=<glob>, os=<os>, or all")
+	deployPluginCmd.Flags().String("ssh-user", "", "SSH user on the hosts (default depends on the host OS)")
+	deployPluginCmd.Flags().String("jumpbox-user", "ubuntu", "SSH user on the range jumpbox")
+	deployPluginCmd.Flags().Int("parallel", 5, "Number of hosts to deploy to at once")
+	deployPluginCmd.Flags().Duration("timeout", 10*time.Minute, "Maximum time to spend on each host")
+	deployPluginCmd.Flags().Bool("sudo", true, "Run the install script with sudo")
+
+	deployPluginCmd.ValidArgsFunction = completeInstalledPluginArg
+	_ = deployPluginCmd.RegisterFlagCompletionFunc("range", completeRangeFlag)
+
+	pluginsCmd.AddCommand(deployPluginCmd)
+}
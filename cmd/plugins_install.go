@@ -0,0 +1,593 @@
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// pluginManifestName is the manifest file at the root of a plugin package.
+const pluginManifestName = "plugin.json"
+
+// PluginManifest describes a plugin package.
+type PluginManifest struct {
+	Name          string            `json:"name"`
+	Version       string            `json:"version"`
+	Description   string            `json:"description,omitempty"`
+	MinCLIVersion string            `json:"min_cli_version,omitempty"`
+	Platforms     []PluginPlatform  `json:"platforms,omitempty"`
+	Checksums     map[string]string `json:"checksums"`
+	Deploy        *PluginDeploy     `json:"deploy,omitempty"`
+}
+
+// PluginPlatform is the plugin executable built for one OS and architecture.
+type PluginPlatform struct {
+	OS     string `json:"os"`
+	Arch   string `json:"arch"`
+	Binary string `json:"binary"`
+}
+
+// PluginDeploy describes the software a plugin installs onto range hosts.
+// Payload is a directory in the package and Install a script inside it.
+type PluginDeploy struct {
+	Payload string   `json:"payload"`
+	Install string   `json:"install"`
+	OS      []string `json:"os,omitempty"`
+}
+
+// InstalledPlugin is an entry in the local plugin index.
+type InstalledPlugin struct {
+	Name        string    `json:"name"`
+	Version     string    `json:"version"`
+	Source      string    `json:"source"`
+	PackageDir  string    `json:"package_dir"`
+	Executable  string    `json:"executable,omitempty"`
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+// pluginIndex tracks plugins installed by the CLI, keyed by name.
+type pluginIndex map[string]InstalledPlugin
+
+var installPluginCmd = &cobra.Command{
+	Use:   "install [archive.tar.gz|directory]",
+	Short: "Install a plugin package",
+	Long: "This command installs a plugin from a local .tar.gz archive or directory. The package must contain a " +
+		"plugin.json manifest; its checksums are verified before anything is installed.",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		force, _ := cmd.Flags().GetBool("force")
+		err := installPlugin(args[0], false, force)
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+var upgradePluginCmd = &cobra.Command{
+	Use:   "upgrade [archive.tar.gz|directory]",
+	Short: "Upgrade an installed plugin",
+	Long:  "This command replaces an installed plugin with a newer version from a local .tar.gz archive or directory.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		force, _ := cmd.Flags().GetBool("force")
+		err := installPlugin(args[0], true, force)
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+var uninstallPluginCmd = &cobra.Command{
+	Use:   "uninstall [plugin-name]",
+	Short: "Uninstall a plugin",
+	Long:  "This command removes a plugin that was installed with 'openlabs plugins install'.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := uninstallPlugin(args[0])
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+// Plugin Installation Implementation.
+func installPlugin(source string, upgrade, force bool) error {
+	packageDir, cleanup, err := openPluginPackage(source)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	manifest, err := readPluginManifest(packageDir)
+	if err != nil {
+		return err
+	}
+
+	index, err := loadPluginIndex()
+	if err != nil {
+		return err
+	}
+
+	existing, installed := index[manifest.Name]
+	switch {
+	case upgrade && !installed:
+		return fmt.Errorf("plugin %s is not installed; use 'openlabs plugins install' instead", manifest.Name)
+	case upgrade && !force && compareVersions(manifest.Version, existing.Version) <= 0:
+		return fmt.Errorf("plugin %s %s is already installed; %s is not newer (use --force to replace it anyway)",
+			manifest.Name, existing.Version, manifest.Version)
+	case !upgrade && installed && !force:
+		return fmt.Errorf("plugin %s %s is already installed; use 'openlabs plugins upgrade' or --force", manifest.Name, existing.Version)
+	}
+
+	if manifest.MinCLIVersion != "" && version != "" && compareVersions(version, manifest.MinCLIVersion) < 0 {
+		return fmt.Errorf("plugin %s %s requires OpenLabs CLI %s or newer (this is %s)",
+			manifest.Name, manifest.Version, manifest.MinCLIVersion, version)
+	}
+
+	if err := verifyPluginChecksums(packageDir, manifest); err != nil {
+		return err
+	}
+
+	binary, err := pluginBinaryForPlatform(manifest)
+	if err != nil {
+		return err
+	}
+
+	pluginsDir, err := getPluginsDir()
+	if err != nil {
+		return err
+	}
+
+	// Keep the whole package so plugins can ship files besides their executable
+	entry := InstalledPlugin{
+		Name:        manifest.Name,
+		Version:     manifest.Version,
+		Source:      source,
+		PackageDir:  filepath.Join(pluginsDir, "packages", manifest.Name),
+		InstalledAt: time.Now().UTC(),
+	}
+	if absSource, err := filepath.Abs(source); err == nil {
+		entry.Source = absSource
+	}
+
+	if err := os.RemoveAll(entry.PackageDir); err != nil {
+		return fmt.Errorf("failed to remove previous package: %s", err)
+	}
+	if err := copyDir(packageDir, entry.PackageDir); err != nil {
+		return fmt.Errorf("failed to install plugin package: %s", err)
+	}
+
+	if binary != "" {
+		entry.Executable = filepath.Join(pluginsDir, pluginPrefix+manifest.Name)
+		if runtime.GOOS == "windows" {
+			entry.Executable += ".exe"
+		}
+		if err := copyFile(filepath.Join(packageDir, binary), entry.Executable, 0755); err != nil {
+			return fmt.Errorf("failed to install plugin executable: %s", err)
+		}
+	} else if existing.Executable != "" {
+		// The new version no longer ships a command
+		_ = os.Remove(existing.Executable)
+	}
+
+	index[manifest.Name] = entry
+	if err := savePluginIndex(index); err != nil {
+		return fmt.Errorf("plugin installed but failed to update plugin index: %s", err)
+	}
+
+	if installed {
+		fmt.Printf("✅ Plugin %s upgraded from %s to %s\n", manifest.Name, existing.Version, manifest.Version)
+	} else {
+		fmt.Printf("✅ Plugin %s %s installed\n", manifest.Name, manifest.Version)
+	}
+	if entry.Executable != "" {
+		fmt.Printf("Run it with 'openlabs %s'\n", manifest.Name)
+	}
+	return nil
+}
+
+func uninstallPlugin(name string) error {
+	index, err := loadPluginIndex()
+	if err != nil {
+		return err
+	}
+
+	entry, ok := index[name]
+	if !ok {
+		if p, found := findPlugin(name); found {
+			return fmt.Errorf("plugin %s was not installed by the CLI; remove %s manually", name, p.Path)
+		}
+		return fmt.Errorf("plugin %s is not installed", name)
+	}
+
+	if entry.Executable != "" {
+		if err := os.Remove(entry.Executable); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove plugin executable: %s", err)
+		}
+	}
+	if err := os.RemoveAll(entry.PackageDir); err != nil {
+		return fmt.Errorf("failed to remove plugin package: %s", err)
+	}
+
+	delete(index, name)
+	if err := savePluginIndex(index); err != nil {
+		return fmt.Errorf("plugin removed but failed to update plugin index: %s", err)
+	}
+
+	fmt.Printf("✅ Plugin %s %s uninstalled\n", name, entry.Version)
+	return nil
+}
+
+// openPluginPackage returns a directory containing the package at source,
+// extracting archives to a temporary directory removed by the returned cleanup.
+func openPluginPackage(source string) (string, func(), error) {
+	noop := func() {}
+
+	info, err := os.Stat(source)
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to open plugin package: %s", err)
+	}
+	if info.IsDir() {
+		return source, noop, nil
+	}
+
+	tmpDir, err := os.MkdirTemp("", "openlabs-plugin-")
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to create temporary directory: %s", err)
+	}
+	cleanup := func() { _ = os.RemoveAll(tmpDir) }
+
+	if err := extractTarGz(source, tmpDir); err != nil {
+		cleanup()
+		return "", noop, fmt.Errorf("failed to extract plugin archive: %s", err)
+	}
+
+	// Archives commonly wrap everything in a single top-level directory
+	root := tmpDir
+	if _, err := os.Stat(filepath.Join(root, pluginManifestName)); os.IsNotExist(err) {
+		entries, _ := os.ReadDir(tmpDir)
+		if len(entries) == 1 && entries[0].IsDir() {
+			root = filepath.Join(tmpDir, entries[0].Name())
+		}
+	}
+
+	return root, cleanup, nil
+}
+
+func extractTarGz(archivePath, dest string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			fmt.Printf("Error closing plugin archive: %v\n", err)
+		}
+	}()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := gz.Close(); err != nil {
+			fmt.Printf("Error closing plugin archive: %v\n", err)
+		}
+	}()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target, err := safeJoin(dest, header.Name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode).Perm())
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(out, tr); err != nil {
+				_ = out.Close()
+				return err
+			}
+			if err := out.Close(); err != nil {
+				return err
+			}
+		default:
+			// Links and special files are not needed by plugins and could escape dest
+			continue
+		}
+	}
+}
+
+// safeJoin joins name onto dir, rejecting names that would escape it.
+func safeJoin(dir, name string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("archive entry %q escapes the package directory", name)
+	}
+	return filepath.Join(dir, cleaned), nil
+}
+
+func readPluginManifest(packageDir string) (PluginManifest, error) {
+	var manifest PluginManifest
+
+	data, err := os.ReadFile(filepath.Join(packageDir, pluginManifestName))
+	if err != nil {
+		return manifest, fmt.Errorf("failed to read plugin manifest: %s", err)
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("failed to parse plugin manifest: %s", err)
+	}
+
+	if manifest.Name == "" || manifest.Version == "" {
+		return manifest, fmt.Errorf("plugin manifest must specify a name and version")
+	}
+	if strings.ContainsAny(manifest.Name, `/\ `) || strings.HasPrefix(manifest.Name, ".") {
+		return manifest, fmt.Errorf("invalid plugin name %q", manifest.Name)
+	}
+	if isBuiltinCommand(manifest.Name) {
+		return manifest, fmt.Errorf("plugin name %q conflicts with a built-in command", manifest.Name)
+	}
+
+	return manifest, nil
+}
+
+// verifyPluginChecksums checks every file listed in the manifest against its
+// SHA-256 checksum. Executables and every file of the deploy payload, which
+// runs on range hosts, must be listed.
+func verifyPluginChecksums(packageDir string, manifest PluginManifest) error {
+	if len(manifest.Checksums) == 0 {
+		return fmt.Errorf("plugin manifest has no checksums")
+	}
+	for _, platform := range manifest.Platforms {
+		if _, ok := manifest.Checksums[platform.Binary]; !ok {
+			return fmt.Errorf("plugin manifest has no checksum for %s", platform.Binary)
+		}
+	}
+
+	if manifest.Deploy != nil {
+		payloadDir, err := safeJoin(packageDir, manifest.Deploy.Payload)
+		if err != nil {
+			return err
+		}
+		listed := map[string]bool{}
+		for name := range manifest.Checksums {
+			listed[path.Clean(filepath.ToSlash(name))] = true
+		}
+		err = filepath.Walk(payloadDir, func(p string, info os.FileInfo, err error) error {
+			if err != nil || !info.Mode().IsRegular() {
+				return err
+			}
+			rel, err := filepath.Rel(packageDir, p)
+			if err != nil {
+				return err
+			}
+			if !listed[filepath.ToSlash(rel)] {
+				return fmt.Errorf("plugin manifest has no checksum for deploy payload file %s", filepath.ToSlash(rel))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for name, want := range manifest.Checksums {
+		path, err := safeJoin(packageDir, name)
+		if err != nil {
+			return err
+		}
+		got, err := fileSHA256(path)
+		if err != nil {
+			return fmt.Errorf("failed to checksum %s: %s", name, err)
+		}
+		if !strings.EqualFold(got, want) {
+			return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", name, want, got)
+		}
+	}
+
+	return nil
+}
+
+// pluginBinaryForPlatform returns the executable for this OS and architecture,
+// or "" when the plugin ships no executable at all.
+func pluginBinaryForPlatform(manifest PluginManifest) (string, error) {
+	if len(manifest.Platforms) == 0 {
+		return "", nil
+	}
+
+	var supported []string
+	for _, platform := range manifest.Platforms {
+		if platform.OS == runtime.GOOS && platform.Arch == runtime.GOARCH {
+			return platform.Binary, nil
+		}
+		supported = append(supported, platform.OS+"/"+platform.Arch)
+	}
+	return "", fmt.Errorf("plugin %s does not support %s/%s (supported: %s)",
+		manifest.Name, runtime.GOOS, runtime.GOARCH, strings.Join(supported, ", "))
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			fmt.Printf("Error closing %s: %v\n", path, err)
+		}
+	}()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := in.Close(); err != nil {
+			fmt.Printf("Error closing %s: %v\n", src, err)
+		}
+	}()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		return copyFile(path, target, info.Mode().Perm())
+	})
+}
+
+// compareVersions compares dotted version numbers, ignoring a leading "v" and
+// any pre-release or build suffix. It returns -1, 0, or 1.
+func compareVersions(a, b string) int {
+	parse := func(v string) []int {
+		v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+		if i := strings.IndexAny(v, "-+"); i >= 0 {
+			v = v[:i]
+		}
+		var parts []int
+		for _, p := range strings.Split(v, ".") {
+			n, _ := strconv.Atoi(p)
+			parts = append(parts, n)
+		}
+		return parts
+	}
+
+	pa, pb := parse(a), parse(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func getPluginIndexPath() (string, error) {
+	pluginsDir, err := getPluginsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(pluginsDir, "index.json"), nil
+}
+
+func loadPluginIndex() (pluginIndex, error) {
+	index := pluginIndex{}
+
+	path, err := getPluginIndexPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return index, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin index: %s", err)
+	}
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse plugin index %s: %s", path, err)
+	}
+	return index, nil
+}
+
+func savePluginIndex(index pluginIndex) error {
+	path, err := getPluginIndexPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func init() {
+	installPluginCmd.Flags().Bool("force", false, "Reinstall the plugin even if it is already installed")
+	upgradePluginCmd.Flags().Bool("force", false, "Replace the plugin even if the package is not a newer version")
+
+	uninstallPluginCmd.ValidArgsFunction = completeInstalledPluginArg
+
+	pluginsCmd.AddCommand(installPluginCmd)
+	pluginsCmd.AddCommand(upgradePluginCmd)
+	pluginsCmd.AddCommand(uninstallPluginCmd)
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// prompter reads answers to interactive prompts from stdin.
+type prompter struct {
+	reader *bufio.Reader
+}
+
+func newPrompter() *prompter {
+	return &prompter{reader: bufio.NewReader(os.Stdin)}
+}
+
+// readLine reads a single trimmed line, accepting a final line without a newline.
+func (p *prompter) readLine() (string, error) {
+	line, err := p.reader.ReadString('\n')
+	if err != nil && !(err == io.EOF && line != "") {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// ask prompts for a value, falling back to def on empty input and re-prompting
+// until validate accepts the answer.
+func (p *prompter) ask(label, def string, validate func(string) error) (string, error) {
+	for {
+		if def != "" {
+			fmt.Printf("%s (%s): ", label, def)
+		} else {
+			fmt.Printf("%s: ", label)
+		}
+
+		answer, err := p.readLine()
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			answer = def
+		}
+
+		if validate != nil {
+			if err := validate(answer); err != nil {
+				fmt.Printf("  ❌ %s\n", err)
+				continue
+			}
+		}
+		return answer, nil
+	}
+}
+
+// askInt prompts for an integer no smaller than min.
+func (p *prompter) askInt(label string, def, min int) (int, error) {
+	answer, err := p.ask(label, strconv.Itoa(def), func(s string) error {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("please enter a number")
+		}
+		if n < min {
+			return fmt.Errorf("must be at least %d", min)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(answer)
+}
+
+// confirm asks a yes/no question.
+func (p *prompter) confirm(label string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+
+	for {
+		fmt.Printf("%s [%s]: ", label, hint)
+		answer, err := p.readLine()
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		default:
+			fmt.Println("  ❌ please answer y or n")
+		}
+	}
+}
+
+// choose shows a numbered pick-list and accepts either the number or the value.
+func (p *prompter) choose(label string, options []string, def string) (string, error) {
+	fmt.Printf("%s:\n", label)
+	for i, option := range options {
+		fmt.Printf("  %2d) %s\n", i+1, option)
+	}
+
+	answer, err := p.ask("Choice", def, func(s string) error {
+		if n, err := strconv.Atoi(s); err == nil && n >= 1 && n <= len(options) {
+			return nil
+		}
+		for _, option := range options {
+			if s == option {
+				return nil
+			}
+		}
+		return fmt.Errorf("please pick a number between 1 and %d", len(options))
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if n, err := strconv.Atoi(answer); err == nil {
+		return options[n-1], nil
+	}
+	return answer, nil
+}
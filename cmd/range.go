@@ -29,6 +29,39 @@ type DeployedRangeHeader struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+// DeployedRange is a deployed range including its network and hosts.
+type DeployedRange struct {
+	DeployedRangeHeader
+	Provider        string             `json:"provider"`
+	Region          string             `json:"region"`
+	JumpboxPublicIP string             `json:"jumpbox_public_ip"`
+	VPCs            []DeployedRangeVPC `json:"vpcs"`
+}
+
+type DeployedRangeVPC struct {
+	Name    string                `json:"name"`
+	CIDR    string                `json:"cidr"`
+	Subnets []DeployedRangeSubnet `json:"subnets"`
+}
+
+type DeployedRangeSubnet struct {
+	Name  string              `json:"name"`
+	CIDR  string              `json:"cidr"`
+	Hosts []DeployedRangeHost `json:"hosts"`
+}
+
+type DeployedRangeHost struct {
+	Hostname  string   `json:"hostname"`
+	OS        string   `json:"os"`
+	Spec      string   `json:"spec"`
+	Size      int      `json:"size"`
+	Tags      []string `json:"tags,omitempty"`
+	IPAddress string   `json:"ip_address"`
+}
+
+// rangeRegions are the regions ranges can be deployed in.
+var rangeRegions = []string{"us_east_1", "us_east_2"}
+
 // Range Commands.
 var rangeCmd = &cobra.Command{
 	Use:   "range",
@@ -49,14 +82,14 @@ var listRangesCmd = &cobra.Command{
 }
 
 var getRangeCmd = &cobra.Command{
-	Use:   "get [range-id]",
+	Use:   "get [range-id|name]",
 	Short: "Get a deployed range",
 	Long:  "This command will get details of a deployed range.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		id, err := strconv.Atoi(args[0])
+		id, err := resolveRangeID(args[0])
 		if err != nil {
-			fmt.Println("Error: range ID must be a number")
+			fmt.Printf("Error: %s\n", err)
 			return
 		}
 		err = getRange(id)
@@ -71,59 +104,118 @@ var deployRangeCmd = &cobra.Command{
 	Short: "Deploy a range",
 	Long:  "This command will deploy a range from a blueprint to the OpenLabs API.",
 	Run: func(cmd *cobra.Command, args []string) {
-		blueprintID, _ := cmd.Flags().GetInt("blueprint-id")
+		blueprintArg, _ := cmd.Flags().GetString("blueprint-id")
 		name, _ := cmd.Flags().GetString("name")
 		region, _ := cmd.Flags().GetString("region")
 		description, _ := cmd.Flags().GetString("description")
+		ttlFlag, _ := cmd.Flags().GetString("ttl")
 
-		if blueprintID == 0 || name == "" || region == "" {
+		if blueprintArg == "" || name == "" || region == "" {
 			fmt.Println("Error: --blueprint-id, --name, and --region are required")
 			return
 		}
+		blueprintID, err := resolveBlueprintID("range", blueprintArg)
+		if err != nil {
+			fmt.Printf("Error: %s\n", err)
+			return
+		}
+
+		var ttl time.Duration
+		if ttlFlag != "" {
+			var err error
+			ttl, err = parseLifetime(ttlFlag)
+			if err != nil {
+				fmt.Printf("Error: invalid --ttl: %s\n", err)
+				return
+			}
+		}
 
-		err := deployRange(blueprintID, name, region, description)
+		err = deployRange(blueprintID, name, region, description, ttl)
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
 
 var deleteRangeCmd = &cobra.Command{
-	Use:   "delete [range-id]",
-	Short: "Delete a deployed range",
-	Long:  "This command will delete a deployed range.",
-	Args:  cobra.ExactArgs(1),
+	Use:   "delete [range-id|name]...",
+	Short: "Delete deployed ranges",
+	Long: "This command will delete one or more deployed ranges. Ranges can be given by ID or name, or selected with " +
+		"--all, --state, --name, --older-than, and --blueprint-id. Selectors narrow the given ranges, or all ranges " +
+		"when none are given. Asks for confirmation unless --yes is set.",
 	Run: func(cmd *cobra.Command, args []string) {
-		id, err := strconv.Atoi(args[0])
-		if err != nil {
-			fmt.Println("Error: range ID must be a number")
+		all, _ := cmd.Flags().GetBool("all")
+		state, _ := cmd.Flags().GetString("state")
+		namePattern, _ := cmd.Flags().GetString("name")
+		olderThanFlag, _ := cmd.Flags().GetString("older-than")
+		blueprintArg, _ := cmd.Flags().GetString("blueprint-id")
+		parallel, _ := cmd.Flags().GetInt("parallel")
+
+		selector := rangeSelector{
+			Args:        args,
+			State:       state,
+			NamePattern: namePattern,
+		}
+		if blueprintArg != "" {
+			blueprintID, err := resolveBlueprintID("range", blueprintArg)
+			if err != nil {
+				fmt.Printf("Error: %s\n", err)
+				return
+			}
+			selector.BlueprintID = blueprintID
+		}
+		if olderThanFlag != "" {
+			olderThan, err := parseLifetime(olderThanFlag)
+			if err != nil {
+				fmt.Printf("Error: invalid --older-than: %s\n", err)
+				return
+			}
+			selector.OlderThan = olderThan
+		}
+
+		if len(args) == 0 && !all && !selector.filtered() {
+			fmt.Println("Error: specify ranges to delete, --all, or a selector such as --state or --name")
 			return
 		}
-		err = deleteRange(id)
+		if len(args) > 0 && all {
+			fmt.Println("Error: --all cannot be combined with range IDs or names")
+			return
+		}
+		if parallel < 1 {
+			fmt.Println("Error: --parallel must be at least 1")
+			return
+		}
+
+		// A single named range keeps the original behavior
+		if len(args) == 1 && !selector.filtered() {
+			id, err := resolveRangeID(args[0])
+			if err != nil {
+				fmt.Printf("Error: %s\n", err)
+				return
+			}
+			if !confirmDestructive(fmt.Sprintf("delete range %d", id), describeRange(id)) {
+				return
+			}
+			err = deleteRange(id)
+			if err != nil {
+				printError(err)
+			}
+			return
+		}
+
+		err := deleteRanges(selector, parallel)
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
 
 // Ranges Implementation.
 func listRanges() error {
-	client := NewClient()
-	resp, err := client.DoRequest("GET", "/api/v1/ranges", nil)
+	ranges, err := fetchRanges()
 	if err != nil {
 		return err
 	}
-	defer func() {
-		err := resp.Body.Close()
-		if err != nil {
-			fmt.Printf("Error closing response body: %v\n", err)
-		}
-	}()
-
-	var ranges []DeployedRangeHeader
-	if err := ParseResponse(resp, &ranges); err != nil {
-		return err
-	}
 
 	if len(ranges) == 0 {
 		fmt.Println("No deployed ranges found")
@@ -147,6 +239,28 @@ func listRanges() error {
 	return nil
 }
 
+// fetchRanges retrieves the headers of all deployed ranges.
+func fetchRanges() ([]DeployedRangeHeader, error) {
+	client := NewClient()
+	resp, err := client.DoRequest("GET", "/api/v1/ranges", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var ranges []DeployedRangeHeader
+	if err := ParseResponse(resp, &ranges); err != nil {
+		return nil, err
+	}
+
+	return ranges, nil
+}
+
 func getRange(id int) error {
 	client := NewClient()
 	resp, err := client.DoRequest("GET", fmt.Sprintf("/api/v1/ranges/%d", id), nil)
@@ -174,18 +288,34 @@ func getRange(id int) error {
 	return nil
 }
 
-func deployRange(blueprintID int, name, region, description string) error {
-	request := DeployRangeRequest{
-		BlueprintID: blueprintID,
-		Name:        name,
-		Region:      region,
-		Description: description,
+// fetchRange retrieves a deployed range with its full VPC, subnet, and host tree.
+func fetchRange(id int) (DeployedRange, error) {
+	client := NewClient()
+	resp, err := client.DoRequest("GET", fmt.Sprintf("/api/v1/ranges/%d", id), nil)
+	if err != nil {
+		return DeployedRange{}, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var deployedRange DeployedRange
+	if err := ParseResponse(resp, &deployedRange); err != nil {
+		return DeployedRange{}, err
 	}
 
+	return deployedRange, nil
+}
+
+// fetchRangeKey retrieves the private SSH key for the hosts of a deployed range.
+func fetchRangeKey(id int) (string, error) {
 	client := NewClient()
-	resp, err := client.DoRequest("POST", "/api/v1/ranges/deploy", request)
+	resp, err := client.DoRequest("GET", fmt.Sprintf("/api/v1/ranges/%d/key", id), nil)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer func() {
 		err := resp.Body.Close()
@@ -194,9 +324,27 @@ func deployRange(blueprintID int, name, region, description string) error {
 		}
 	}()
 
-	// Response is a deployment status object
-	var result map[string]interface{}
+	var result struct {
+		RangePrivateKey string `json:"range_private_key"`
+	}
 	if err := ParseResponse(resp, &result); err != nil {
+		return "", err
+	}
+	if result.RangePrivateKey == "" {
+		return "", fmt.Errorf("the API did not return a key for range %d", id)
+	}
+
+	return result.RangePrivateKey, nil
+}
+
+func deployRange(blueprintID int, name, region, description string, ttl time.Duration) error {
+	result, err := submitRangeDeploy(DeployRangeRequest{
+		BlueprintID: blueprintID,
+		Name:        name,
+		Region:      region,
+		Description: description,
+	})
+	if err != nil {
 		return err
 	}
 
@@ -209,14 +357,38 @@ func deployRange(blueprintID int, name, region, description string) error {
 	fmt.Println("Deployment status:")
 	fmt.Println(prettyJSON)
 
+	if ttl > 0 {
+		id, ok := deployedRangeID(result)
+		if !ok {
+			return fmt.Errorf("range deployed but the API did not return its ID; set its TTL with 'openlabs range extend'")
+		}
+		expiresAt := time.Now().Add(ttl).UTC()
+		if err := recordRangeExpiry(id, name, expiresAt); err != nil {
+			return fmt.Errorf("range deployed but its TTL was not recorded: %s", err)
+		}
+		fmt.Printf("Range expires at %s; delete expired ranges with 'openlabs reaper'\n", expiresAt.Local().Format(time.RFC1123))
+	}
+
 	return nil
 }
 
-func deleteRange(id int) error {
+// deployedRangeID returns the ID of the range a deployment created, if the
+// deployment status includes it.
+func deployedRangeID(deployStatus map[string]interface{}) (int, bool) {
+	for _, key := range []string{"id", "range_id"} {
+		if v, ok := deployStatus[key].(float64); ok {
+			return int(v), true
+		}
+	}
+	return 0, false
+}
+
+// submitRangeDeploy starts a range deployment and returns the deployment status.
+func submitRangeDeploy(request DeployRangeRequest) (map[string]interface{}, error) {
 	client := NewClient()
-	resp, err := client.DoRequest("DELETE", fmt.Sprintf("/api/v1/ranges/%d", id), nil)
+	resp, err := client.DoRequest("POST", "/api/v1/ranges/deploy", request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() {
 		err := resp.Body.Close()
@@ -225,8 +397,17 @@ func deleteRange(id int) error {
 		}
 	}()
 
-	var result bool
+	// Response is a deployment status object
+	var result map[string]interface{}
 	if err := ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func deleteRange(id int) error {
+	result, err := submitRangeDelete(id)
+	if err != nil {
 		return err
 	}
 
@@ -238,12 +419,55 @@ func deleteRange(id int) error {
 	return nil
 }
 
+// submitRangeDelete asks the API to destroy a range and reports whether it accepted.
+func submitRangeDelete(id int) (bool, error) {
+	client := NewClient()
+	resp, err := client.DoRequest("DELETE", fmt.Sprintf("/api/v1/ranges/%d", id), nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var result bool
+	if err := ParseResponse(resp, &result); err != nil {
+		return false, err
+	}
+
+	if result {
+		if err := clearRangeExpiry(id); err != nil && Debug {
+			fmt.Printf("DEBUG: Failed to clear expiry of range %d: %s\n", id, err)
+		}
+	}
+	return result, nil
+}
+
 func init() {
 	// Deploy command flags
-	deployRangeCmd.Flags().Int("blueprint-id", 0, "ID of the blueprint to deploy")
+	deployRangeCmd.Flags().String("blueprint-id", "", "ID or name of the blueprint to deploy")
 	deployRangeCmd.Flags().String("name", "", "Name for the deployed range")
 	deployRangeCmd.Flags().String("region", "", "Region to deploy the range in (e.g., us_east_1)")
 	deployRangeCmd.Flags().String("description", "", "Optional description for the range")
+	deployRangeCmd.Flags().String("ttl", "", "Delete the range after this long (e.g., 4h, 90m, 2d)")
+
+	// Delete command flags
+	deleteRangeCmd.Flags().Bool("all", false, "Delete all deployed ranges")
+	deleteRangeCmd.Flags().String("state", "", "Only delete ranges in this state (e.g., on, off, failed)")
+	deleteRangeCmd.Flags().String("name", "", "Only delete ranges whose name matches this glob (e.g., 'class-*')")
+	deleteRangeCmd.Flags().String("older-than", "", "Only delete ranges created longer ago than this (e.g., 24h, 7d)")
+	deleteRangeCmd.Flags().String("blueprint-id", "", "Only delete ranges deployed from this blueprint (ID or name)")
+	deleteRangeCmd.Flags().Int("parallel", 4, "Number of ranges to delete at once")
+
+	// Shell completion
+	getRangeCmd.ValidArgsFunction = completeRangeArg
+	deleteRangeCmd.ValidArgsFunction = completeRangeArgs
+	_ = deleteRangeCmd.RegisterFlagCompletionFunc("blueprint-id", completeRangeBlueprintFlag)
+	_ = deployRangeCmd.RegisterFlagCompletionFunc("blueprint-id", completeRangeBlueprintFlag)
+	_ = deployRangeCmd.RegisterFlagCompletionFunc("region", completeValues(rangeRegions...))
 
 	// Add subcommands to range command
 	rangeCmd.AddCommand(listRangesCmd)
@@ -0,0 +1,734 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// Batch participant states.
+const (
+	batchPending  = "pending"
+	batchDeployed = "deployed"
+	batchFailed   = "failed"
+	batchDeleted  = "deleted"
+)
+
+// rangeBatch is the local record of a batch deployment, saved after every
+// change so an interrupted run can be resumed.
+type rangeBatch struct {
+	Name         string              `json:"name"`
+	APIURL       string              `json:"api_url"`
+	BlueprintID  int                 `json:"blueprint_id"`
+	Region       string              `json:"region"`
+	Description  string              `json:"description,omitempty"`
+	Source       string              `json:"source"`
+	CreatedAt    time.Time           `json:"created_at"`
+	Participants []*batchParticipant `json:"participants"`
+
+	mu sync.Mutex
+}
+
+// batchParticipant is one person in a batch and the range deployed for them.
+type batchParticipant struct {
+	Name      string    `json:"name"`
+	Email     string    `json:"email,omitempty"`
+	RangeName string    `json:"range_name"`
+	RangeID   int       `json:"range_id,omitempty"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+var batchNamePattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+var deployBatchRangeCmd = &cobra.Command{
+	Use:   "deploy-batch",
+	Short: "Deploy one range per participant",
+	Long: "This command deploys a range from the same blueprint for every member of a workspace or every row of a " +
+		"participants CSV file (columns: name, email). Progress is saved to ~/.openlabs/batches/<batch>.json; " +
+		"running the same command again resumes the batch, skipping ranges that were already deployed.",
+	Run: func(cmd *cobra.Command, args []string) {
+		blueprintArg, _ := cmd.Flags().GetString("blueprint-id")
+		region, _ := cmd.Flags().GetString("region")
+		description, _ := cmd.Flags().GetString("description")
+		workspaceArg, _ := cmd.Flags().GetString("workspace")
+		participantsFile, _ := cmd.Flags().GetString("participants")
+		batchName, _ := cmd.Flags().GetString("batch")
+		parallel, _ := cmd.Flags().GetInt("parallel")
+
+		if blueprintArg == "" || region == "" {
+			fmt.Println("Error: --blueprint-id and --region are required")
+			return
+		}
+		if (workspaceArg == "") == (participantsFile == "") {
+			fmt.Println("Error: use exactly one of --workspace or --participants")
+			return
+		}
+		if parallel < 1 {
+			fmt.Println("Error: --parallel must be at least 1")
+			return
+		}
+
+		blueprintID, err := resolveBlueprintID("range", blueprintArg)
+		if err != nil {
+			fmt.Printf("Error: %s\n", err)
+			return
+		}
+		var workspaceID int
+		if workspaceArg != "" {
+			workspaceID, err = resolveWorkspaceID(workspaceArg)
+			if err != nil {
+				fmt.Printf("Error: %s\n", err)
+				return
+			}
+		}
+
+		err = deployRangeBatch(blueprintID, region, description, workspaceID, participantsFile, batchName, parallel)
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+var batchStatusRangeCmd = &cobra.Command{
+	Use:   "batch-status [batch]",
+	Short: "Show the progress of batch deployments",
+	Long:  "This command lists all local batch deployments, or shows every participant of one batch.",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var err error
+		if len(args) == 0 {
+			err = listRangeBatches()
+		} else {
+			err = showRangeBatch(args[0])
+		}
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+var teardownBatchRangeCmd = &cobra.Command{
+	Use:   "teardown-batch [batch]",
+	Short: "Delete every range deployed by a batch",
+	Long:  "This command deletes all ranges deployed by a batch and removes its local state once they are gone.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		parallel, _ := cmd.Flags().GetInt("parallel")
+		if parallel < 1 {
+			fmt.Println("Error: --parallel must be at least 1")
+			return
+		}
+
+		err := teardownRangeBatch(args[0], parallel)
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+// Range Batch Implementation.
+func deployRangeBatch(blueprintID int, region, description string, workspaceID int, participantsFile, batchName string, parallel int) error {
+	var participants []*batchParticipant
+	var source string
+	var err error
+
+	if workspaceID != 0 {
+		source = fmt.Sprintf("workspace %d", workspaceID)
+		participants, err = workspaceParticipants(workspaceID)
+	} else {
+		source = participantsFile
+		participants, err = readParticipantsCSV(participantsFile)
+	}
+	if err != nil {
+		return err
+	}
+	if len(participants) == 0 {
+		return fmt.Errorf("no participants found in %s", source)
+	}
+
+	if batchName == "" {
+		batchName = defaultBatchName(blueprintID, workspaceID, participantsFile)
+	}
+	if err := validateBatchName(batchName); err != nil {
+		return err
+	}
+
+	batch, err := loadRangeBatch(batchName)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		batch = &rangeBatch{
+			Name:        batchName,
+			APIURL:      NewClient().BaseURL,
+			BlueprintID: blueprintID,
+			Region:      region,
+			Description: description,
+			Source:      source,
+			CreatedAt:   time.Now().UTC(),
+		}
+	case err != nil:
+		return err
+	default:
+		if batch.APIURL != NewClient().BaseURL {
+			return fmt.Errorf("batch %s was deployed to %s; choose another --batch name", batchName, batch.APIURL)
+		}
+		if batch.BlueprintID != blueprintID || batch.Region != region {
+			return fmt.Errorf("batch %s already deploys blueprint %d in %s; choose another --batch name",
+				batchName, batch.BlueprintID, batch.Region)
+		}
+		fmt.Printf("Resuming batch %s\n", batchName)
+	}
+
+	batch.addParticipants(participants)
+	if !DryRun {
+		if err := batch.save(); err != nil {
+			return err
+		}
+	}
+
+	// Ranges may have been created by a run that was interrupted before it could record them
+	todo := batch.withStatus(batchPending, batchFailed)
+	if len(todo) > 0 {
+		if err := batch.adoptExistingRanges(); err != nil {
+			return err
+		}
+		todo = batch.withStatus(batchPending, batchFailed)
+	}
+
+	if DryRun {
+		fmt.Printf("The following %d range(s) from blueprint %d in %s would be deployed (batch %s):\n", len(todo), blueprintID, region, batchName)
+		for _, p := range todo {
+			fmt.Printf("  %s for %s\n", p.RangeName, p.Name)
+		}
+		fmt.Println("Dry run: no ranges were deployed and the batch was not saved")
+		return nil
+	}
+
+	if len(todo) == 0 {
+		fmt.Printf("All %d ranges in batch %s are already deployed\n", len(batch.Participants), batchName)
+		return batch.printSummary()
+	}
+
+	fmt.Printf("Deploying %d range(s) from blueprint %d in %s (batch %s)...\n", len(todo), blueprintID, region, batchName)
+
+	batch.forEach(todo, parallel, func(p *batchParticipant) {
+		result, err := submitRangeDeploy(DeployRangeRequest{
+			BlueprintID: batch.BlueprintID,
+			Name:        p.RangeName,
+			Region:      batch.Region,
+			Description: batch.Description,
+		})
+
+		batch.update(p, func() {
+			if err != nil {
+				p.Status = batchFailed
+				p.Error = err.Error()
+				return
+			}
+			p.Status = batchDeployed
+			p.Error = ""
+			if id, ok := deployedRangeID(result); ok {
+				p.RangeID = id
+			}
+		})
+		fmt.Printf("  %s %s\n", batchStatusIcon(p.Status), p.RangeName)
+	})
+
+	return batch.printSummary()
+}
+
+func teardownRangeBatch(name string, parallel int) error {
+	batch, err := loadRangeBatch(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("batch %s not found", name)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Look up ranges whose IDs were not returned when they were deployed
+	if err := batch.adoptExistingRanges(); err != nil {
+		return err
+	}
+
+	todo := batch.withStatus(batchDeployed)
+	if DryRun {
+		fmt.Printf("The following %d range(s) from batch %s would be deleted:\n", len(todo), name)
+		for _, p := range todo {
+			fmt.Printf("  %s (%d)\n", p.RangeName, p.RangeID)
+		}
+		fmt.Println("Dry run: no ranges were deleted")
+		return nil
+	}
+
+	if len(todo) == 0 {
+		fmt.Printf("Batch %s has no deployed ranges\n", name)
+	} else {
+		listing := func() []string {
+			lines := make([]string, 0, len(todo))
+			for _, p := range todo {
+				lines = append(lines, fmt.Sprintf("%s (%d) for %s", p.RangeName, p.RangeID, p.Name))
+			}
+			return lines
+		}
+		if !confirmDestructive(fmt.Sprintf("delete %d range(s) from batch %s", len(todo), name), listing) {
+			return nil
+		}
+		fmt.Printf("Deleting %d range(s) from batch %s...\n", len(todo), name)
+	}
+
+	batch.forEach(todo, parallel, func(p *batchParticipant) {
+		var err error
+		if p.RangeID == 0 {
+			err = fmt.Errorf("range %s was not found", p.RangeName)
+		} else {
+			var deleted bool
+			deleted, err = submitRangeDelete(p.RangeID)
+			if err == nil && !deleted {
+				err = fmt.Errorf("the API did not delete range %d", p.RangeID)
+			}
+		}
+
+		batch.update(p, func() {
+			if err != nil {
+				p.Error = err.Error()
+				return
+			}
+			p.Status = batchDeleted
+			p.Error = ""
+		})
+		icon := "✅"
+		if err != nil {
+			icon = "❌"
+		}
+		fmt.Printf("  %s %s\n", icon, p.RangeName)
+	})
+
+	if len(batch.withStatus(batchDeployed)) > 0 {
+		if err := batch.printSummary(); err != nil {
+			return err
+		}
+		return fmt.Errorf("some ranges in batch %s could not be deleted; run teardown-batch again to retry", name)
+	}
+
+	path, err := getRangeBatchPath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("ranges deleted but failed to remove batch state: %s", err)
+	}
+	fmt.Printf("✅ Batch %s torn down\n", name)
+	return nil
+}
+
+func listRangeBatches() error {
+	dir, err := getRangeBatchesDir()
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	var batches []*rangeBatch
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		batch, err := loadRangeBatch(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			fmt.Printf("Warning: %s\n", err)
+			continue
+		}
+		batches = append(batches, batch)
+	}
+
+	if len(batches) == 0 {
+		fmt.Println("No batch deployments found")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Batch", "Blueprint", "Region", "Source", "Deployed", "Failed", "Pending", "Created At"})
+	for _, b := range batches {
+		counts := b.counts()
+		table.Append([]string{
+			b.Name,
+			strconv.Itoa(b.BlueprintID),
+			b.Region,
+			b.Source,
+			strconv.Itoa(counts[batchDeployed]),
+			strconv.Itoa(counts[batchFailed]),
+			strconv.Itoa(counts[batchPending]),
+			b.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	table.Render()
+	return nil
+}
+
+func showRangeBatch(name string) error {
+	batch, err := loadRangeBatch(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("batch %s not found", name)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Batch %s: blueprint %d in %s from %s\n", batch.Name, batch.BlueprintID, batch.Region, batch.Source)
+	return batch.printSummary()
+}
+
+// workspaceParticipants returns one participant per workspace member.
+func workspaceParticipants(workspaceID int) ([]*batchParticipant, error) {
+	users, err := fetchWorkspaceUsers(workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users of workspace %d: %s", workspaceID, err)
+	}
+
+	participants := make([]*batchParticipant, 0, len(users))
+	for _, u := range users {
+		participants = append(participants, &batchParticipant{Name: u.Name, Email: u.Email})
+	}
+	return participants, nil
+}
+
+// readParticipantsCSV reads participants from a CSV file with a name column
+// and an optional email column. A header row is used when present.
+func readParticipantsCSV(path string) ([]*batchParticipant, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open participants file: %s", err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			fmt.Printf("Error closing participants file: %v\n", err)
+		}
+	}()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	nameCol, emailCol := 0, 1
+	var participants []*batchParticipant
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read participants file: %s", err)
+		}
+
+		if line == 1 && isParticipantsHeader(record) {
+			nameCol, emailCol = -1, -1
+			for i, col := range record {
+				switch strings.ToLower(strings.TrimSpace(col)) {
+				case "name":
+					nameCol = i
+				case "email":
+					emailCol = i
+				}
+			}
+			if nameCol == -1 && emailCol == -1 {
+				return nil, fmt.Errorf("participants file needs a name or email column")
+			}
+			continue
+		}
+
+		p := &batchParticipant{Name: csvField(record, nameCol), Email: csvField(record, emailCol)}
+		if p.Name == "" && p.Email == "" {
+			continue
+		}
+		participants = append(participants, p)
+	}
+	return participants, nil
+}
+
+func isParticipantsHeader(record []string) bool {
+	for _, col := range record {
+		switch strings.ToLower(strings.TrimSpace(col)) {
+		case "name", "email":
+			return true
+		}
+	}
+	return false
+}
+
+func csvField(record []string, col int) string {
+	if col < 0 || col >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[col])
+}
+
+func defaultBatchName(blueprintID, workspaceID int, participantsFile string) string {
+	if workspaceID != 0 {
+		return fmt.Sprintf("workspace-%d-blueprint-%d", workspaceID, blueprintID)
+	}
+	base := strings.TrimSuffix(filepath.Base(participantsFile), filepath.Ext(participantsFile))
+	return fmt.Sprintf("%s-blueprint-%d", slugify(base), blueprintID)
+}
+
+func validateBatchName(name string) error {
+	if name == "" || slugify(name) != name {
+		return fmt.Errorf("invalid batch name %q; use lowercase letters, digits, and dashes", name)
+	}
+	return nil
+}
+
+// slugify lowercases s and replaces runs of other characters with dashes.
+func slugify(s string) string {
+	return strings.Trim(batchNamePattern.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
+// addParticipants adds participants not already in the batch, giving each a
+// unique range name.
+func (b *rangeBatch) addParticipants(participants []*batchParticipant) {
+	known := map[string]bool{}
+	taken := map[string]bool{}
+	for _, p := range b.Participants {
+		known[participantKey(p)] = true
+		taken[p.RangeName] = true
+	}
+
+	for _, p := range participants {
+		if known[participantKey(p)] {
+			continue
+		}
+		known[participantKey(p)] = true
+
+		label := p.Name
+		if label == "" {
+			label, _, _ = strings.Cut(p.Email, "@")
+		}
+		base := b.Name + "-" + slugify(label)
+		p.RangeName = base
+		for i := 2; taken[p.RangeName]; i++ {
+			p.RangeName = fmt.Sprintf("%s-%d", base, i)
+		}
+		taken[p.RangeName] = true
+
+		p.Status = batchPending
+		p.UpdatedAt = time.Now().UTC()
+		b.Participants = append(b.Participants, p)
+	}
+}
+
+func participantKey(p *batchParticipant) string {
+	if p.Email != "" {
+		return "email:" + strings.ToLower(p.Email)
+	}
+	return "name:" + strings.ToLower(p.Name)
+}
+
+// adoptExistingRanges matches participants to deployed ranges by name,
+// recording range IDs and ranges that were created but never recorded.
+func (b *rangeBatch) adoptExistingRanges() error {
+	ranges, err := fetchRanges()
+	if err != nil {
+		return fmt.Errorf("failed to list deployed ranges: %s", err)
+	}
+
+	byName := map[string]DeployedRangeHeader{}
+	for _, r := range ranges {
+		byName[r.Name] = r
+	}
+
+	changed := false
+	for _, p := range b.Participants {
+		r, ok := byName[p.RangeName]
+		if !ok || p.Status == batchDeleted {
+			continue
+		}
+		if p.Status != batchDeployed || p.RangeID != r.ID {
+			p.Status = batchDeployed
+			p.RangeID = r.ID
+			p.Error = ""
+			p.UpdatedAt = time.Now().UTC()
+			changed = true
+		}
+	}
+
+	if changed && !DryRun {
+		return b.save()
+	}
+	return nil
+}
+
+// forEach runs fn for each participant with at most parallel running at once.
+func (b *rangeBatch) forEach(participants []*batchParticipant, parallel int, fn func(p *batchParticipant)) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallel)
+
+	for _, p := range participants {
+		wg.Add(1)
+		go func(p *batchParticipant) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			fn(p)
+		}(p)
+	}
+	wg.Wait()
+}
+
+// update applies a change to a participant and saves the batch.
+func (b *rangeBatch) update(p *batchParticipant, change func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	change()
+	p.UpdatedAt = time.Now().UTC()
+	if err := b.saveLocked(); err != nil {
+		fmt.Printf("Warning: failed to save batch state: %s\n", err)
+	}
+}
+
+func (b *rangeBatch) withStatus(statuses ...string) []*batchParticipant {
+	var matched []*batchParticipant
+	for _, p := range b.Participants {
+		if containsString(statuses, p.Status) {
+			matched = append(matched, p)
+		}
+	}
+	return matched
+}
+
+func (b *rangeBatch) counts() map[string]int {
+	counts := map[string]int{}
+	for _, p := range b.Participants {
+		counts[p.Status]++
+	}
+	return counts
+}
+
+func (b *rangeBatch) printSummary() error {
+	participants := append([]*batchParticipant(nil), b.Participants...)
+	sort.SliceStable(participants, func(i, j int) bool { return participants[i].RangeName < participants[j].RangeName })
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Participant", "Email", "Range", "Range ID", "Status", "Error"})
+	for _, p := range participants {
+		rangeID := ""
+		if p.RangeID != 0 {
+			rangeID = strconv.Itoa(p.RangeID)
+		}
+		table.Append([]string{p.Name, p.Email, p.RangeName, rangeID, p.Status, p.Error})
+	}
+	table.Render()
+
+	counts := b.counts()
+	fmt.Printf("%d deployed, %d failed, %d pending, %d deleted\n",
+		counts[batchDeployed], counts[batchFailed], counts[batchPending], counts[batchDeleted])
+	if counts[batchFailed] > 0 {
+		return fmt.Errorf("%d range(s) failed to deploy; run the same command again to retry", counts[batchFailed])
+	}
+	return nil
+}
+
+func batchStatusIcon(status string) string {
+	if status == batchDeployed {
+		return "✅"
+	}
+	return "❌"
+}
+
+func getRangeBatchesDir() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "batches"), nil
+}
+
+func getRangeBatchPath(name string) (string, error) {
+	dir, err := getRangeBatchesDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".json"), nil
+}
+
+func loadRangeBatch(name string) (*rangeBatch, error) {
+	path, err := getRangeBatchPath(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var batch rangeBatch
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return nil, fmt.Errorf("failed to read batch state %s: %s", path, err)
+	}
+	return &batch, nil
+}
+
+func (b *rangeBatch) save() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.saveLocked()
+}
+
+// saveLocked writes the batch through a temporary file so an interrupted
+// write never leaves a truncated state file behind.
+func (b *rangeBatch) saveLocked() error {
+	path, err := getRangeBatchPath(b.Name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func init() {
+	deployBatchRangeCmd.Flags().String("blueprint-id", "", "ID or name of the blueprint to deploy")
+	deployBatchRangeCmd.Flags().String("region", "", "Region to deploy the ranges in (e.g., us_east_1)")
+	deployBatchRangeCmd.Flags().String("description", "", "Optional description for the ranges")
+	deployBatchRangeCmd.Flags().String("workspace", "", "Deploy a range for every member of this workspace (ID or name)")
+	deployBatchRangeCmd.Flags().String("participants", "", "CSV file of participants (columns: name, email)")
+	deployBatchRangeCmd.Flags().String("batch", "", "Name of the batch, used to resume it and in range names")
+	deployBatchRangeCmd.Flags().Int("parallel", 4, "Number of ranges to deploy at the same time")
+
+	teardownBatchRangeCmd.Flags().Int("parallel", 4, "Number of ranges to delete at the same time")
+
+	_ = deployBatchRangeCmd.RegisterFlagCompletionFunc("blueprint-id", completeRangeBlueprintFlag)
+	_ = deployBatchRangeCmd.RegisterFlagCompletionFunc("region", completeValues(rangeRegions...))
+	_ = deployBatchRangeCmd.RegisterFlagCompletionFunc("workspace", completeWorkspaceFlag)
+
+	rangeCmd.AddCommand(deployBatchRangeCmd)
+	rangeCmd.AddCommand(batchStatusRangeCmd)
+	rangeCmd.AddCommand(teardownBatchRangeCmd)
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// rangeSelector picks deployed ranges by ID, name, or attribute.
+type rangeSelector struct {
+	Args        []string
+	State       string
+	NamePattern string
+	OlderThan   time.Duration
+	BlueprintID int
+}
+
+// rangeDeleteResult is the outcome of deleting one range.
+type rangeDeleteResult struct {
+	Range DeployedRangeHeader
+	Err   error
+}
+
+// filtered reports whether any attribute selectors are set.
+func (s rangeSelector) filtered() bool {
+	return s.State != "" || s.NamePattern != "" || s.OlderThan > 0 || s.BlueprintID != 0
+}
+
+// Range Delete Implementation.
+func deleteRanges(selector rangeSelector, parallel int) error {
+	ranges, err := selectRanges(selector)
+	if err != nil {
+		return err
+	}
+	if len(ranges) == 0 {
+		fmt.Println("No ranges match the selection")
+		return nil
+	}
+
+	if DryRun {
+		fmt.Printf("The following %d range(s) would be deleted:\n", len(ranges))
+		printRangeSelection(os.Stdout, ranges)
+		fmt.Println("Dry run: no ranges were deleted")
+		return nil
+	}
+
+	listing := func() []string {
+		var b strings.Builder
+		printRangeSelection(&b, ranges)
+		return strings.Split(strings.TrimRight(b.String(), "\n"), "\n")
+	}
+	if !confirmDestructive(fmt.Sprintf("delete %d range(s)", len(ranges)), listing) {
+		return nil
+	}
+
+	results := make([]rangeDeleteResult, len(ranges))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallel)
+	for i, r := range ranges {
+		wg.Add(1)
+		go func(i int, r DeployedRangeHeader) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			deleted, err := submitRangeDelete(r.ID)
+			if err == nil && !deleted {
+				err = fmt.Errorf("the API did not delete the range")
+			}
+			results[i] = rangeDeleteResult{Range: r, Err: err}
+
+			icon := "✅"
+			if err != nil {
+				icon = "❌"
+			}
+			fmt.Printf("  %s %s (%d)\n", icon, r.Name, r.ID)
+		}(i, r)
+	}
+	wg.Wait()
+
+	return printRangeDeleteSummary(results)
+}
+
+// selectRanges returns the ranges named by the selector's arguments, or all
+// ranges when there are none, narrowed by its attribute selectors.
+func selectRanges(selector rangeSelector) ([]DeployedRangeHeader, error) {
+	ranges, err := fetchRanges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ranges: %s", err)
+	}
+
+	candidates := ranges
+	if len(selector.Args) > 0 {
+		byID := make(map[int]DeployedRangeHeader, len(ranges))
+		resources := make([]namedResource, 0, len(ranges))
+		for _, r := range ranges {
+			byID[r.ID] = r
+			resources = append(resources, namedResource{ID: r.ID, Name: r.Name})
+		}
+
+		candidates = nil
+		seen := map[int]bool{}
+		for _, arg := range selector.Args {
+			id, err := resolveID("range", arg, func() ([]namedResource, error) { return resources, nil })
+			if err != nil {
+				return nil, err
+			}
+			r, ok := byID[id]
+			if !ok {
+				return nil, fmt.Errorf("range %d not found", id)
+			}
+			if !seen[id] {
+				seen[id] = true
+				candidates = append(candidates, r)
+			}
+		}
+	}
+
+	var selected []DeployedRangeHeader
+	for _, r := range candidates {
+		match, err := selector.matches(r)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			selected = append(selected, r)
+		}
+	}
+
+	sort.Slice(selected, func(i, j int) bool { return selected[i].ID < selected[j].ID })
+	return selected, nil
+}
+
+func (s rangeSelector) matches(r DeployedRangeHeader) (bool, error) {
+	if s.State != "" && !strings.EqualFold(r.State, s.State) {
+		return false, nil
+	}
+	if s.BlueprintID != 0 && r.BlueprintID != s.BlueprintID {
+		return false, nil
+	}
+	if s.OlderThan > 0 && time.Since(r.CreatedAt) < s.OlderThan {
+		return false, nil
+	}
+	if s.NamePattern != "" {
+		match, err := path.Match(s.NamePattern, r.Name)
+		if err != nil {
+			return false, fmt.Errorf("invalid --name pattern %q: %s", s.NamePattern, err)
+		}
+		if !match {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func printRangeSelection(w io.Writer, ranges []DeployedRangeHeader) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Name", "State", "Blueprint ID", "Age"})
+	for _, r := range ranges {
+		table.Append([]string{
+			strconv.Itoa(r.ID),
+			r.Name,
+			r.State,
+			strconv.Itoa(r.BlueprintID),
+			formatRemaining(time.Since(r.CreatedAt)),
+		})
+	}
+	table.Render()
+}
+
+func printRangeDeleteSummary(results []rangeDeleteResult) error {
+	var failed int
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Name", "Result", "Error"})
+	for _, res := range results {
+		status, msg := "✅ Deleted", ""
+		if res.Err != nil {
+			failed++
+			status, msg = "❌ Failed", res.Err.Error()
+		}
+		table.Append([]string{strconv.Itoa(res.Range.ID), res.Range.Name, status, msg})
+	}
+
+	fmt.Println()
+	table.Render()
+	fmt.Printf("%d deleted, %d failed\n", len(results)-failed, failed)
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d range(s)", failed)
+	}
+	return nil
+}
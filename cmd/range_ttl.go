@@ -0,0 +1,531 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// rangeExpiry is the time after which a range should be deleted.
+type rangeExpiry struct {
+	RangeID   int       `json:"range_id"`
+	Name      string    `json:"name"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Source    string    `json:"-"`
+}
+
+// expiryIndex maps API URL to range ID to the expiry of that range.
+// The API does not enforce range lifetimes, so TTLs are tracked locally.
+type expiryIndex map[string]map[string]rangeExpiry
+
+// workspaceLimit is the shortest time limit that applies to ranges deployed
+// from a blueprint, and the workspace that sets it.
+type workspaceLimit struct {
+	Limit     time.Duration
+	Workspace string
+}
+
+// expiryMu serializes updates to the expiry index from concurrent deletes.
+var expiryMu sync.Mutex
+
+var extendRangeCmd = &cobra.Command{
+	Use:   "extend [range-id|name]",
+	Short: "Extend the lifetime of a range",
+	Long:  "This command pushes back the time at which a range expires. Ranges without a TTL get one counted from now.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := resolveRangeID(args[0])
+		if err != nil {
+			fmt.Printf("Error: %s\n", err)
+			return
+		}
+		byFlag, _ := cmd.Flags().GetString("by")
+		if byFlag == "" {
+			fmt.Println("Error: --by is required")
+			return
+		}
+		by, err := parseLifetime(byFlag)
+		if err != nil {
+			fmt.Printf("Error: invalid --by: %s\n", err)
+			return
+		}
+
+		err = extendRange(id, by)
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+var expiringRangesCmd = &cobra.Command{
+	Use:   "expiring",
+	Short: "List ranges nearing their time limit",
+	Long: "This command lists ranges that expire within the given window, including ranges that have already expired. " +
+		"A range expires at its TTL. With --include-workspace-limits, ranges without a TTL expire at the time limit " +
+		"of a workspace their blueprint is shared in.",
+	Run: func(cmd *cobra.Command, args []string) {
+		withinFlag, _ := cmd.Flags().GetString("within")
+		all, _ := cmd.Flags().GetBool("all")
+		includeWorkspaces, _ := cmd.Flags().GetBool("include-workspace-limits")
+
+		within, err := parseLifetime(withinFlag)
+		if err != nil {
+			fmt.Printf("Error: invalid --within: %s\n", err)
+			return
+		}
+
+		err = listExpiringRanges(within, all, includeWorkspaces)
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+var reaperCmd = &cobra.Command{
+	Use:   "reaper",
+	Short: "Delete ranges whose TTL has passed",
+	Long: "This command deletes every range whose TTL, set with 'openlabs range deploy --ttl', has passed. With " +
+		"--include-workspace-limits, ranges without a TTL that are older than the time limit of a workspace their " +
+		"blueprint is shared in are deleted too. Run it from cron with --yes, or keep it running with --interval.",
+	Run: func(cmd *cobra.Command, args []string) {
+		interval, _ := cmd.Flags().GetDuration("interval")
+		includeWorkspaces, _ := cmd.Flags().GetBool("include-workspace-limits")
+
+		if interval <= 0 {
+			if err := reapExpiredRanges(DryRun, includeWorkspaces); err != nil {
+				fmt.Println(err)
+			}
+			return
+		}
+
+		fmt.Printf("Reaping expired ranges every %s (Ctrl+C to stop)\n", interval)
+		stop := make(chan os.Signal, 1)
+		signal.Notify(stop, os.Interrupt)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := reapExpiredRanges(DryRun, includeWorkspaces); err != nil {
+				fmt.Println(err)
+			}
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	},
+}
+
+// Range Lifetime Implementation.
+func extendRange(id int, by time.Duration) error {
+	index := loadExpiryIndex()
+	entries := index.forAPI(NewClient().BaseURL)
+	key := strconv.Itoa(id)
+
+	entry, ok := entries[key]
+	if !ok {
+		r, err := fetchRange(id)
+		if err != nil {
+			return fmt.Errorf("failed to get range %d: %s", id, err)
+		}
+		entry = rangeExpiry{RangeID: id, Name: r.Name}
+		if limit, ok := workspaceTimeLimits()[r.BlueprintID]; ok {
+			entry.ExpiresAt = r.CreatedAt.Add(limit.Limit)
+		}
+	}
+
+	// Extending an expired range counts from now rather than from the past expiry
+	base := time.Now().UTC()
+	if entry.ExpiresAt.After(base) {
+		base = entry.ExpiresAt
+	}
+	entry.ExpiresAt = base.Add(by)
+	entries[key] = entry
+
+	if DryRun {
+		fmt.Printf("Range %d would expire at %s (in %s)\n", id, entry.ExpiresAt.Local().Format(time.RFC1123), formatRemaining(time.Until(entry.ExpiresAt)))
+		return nil
+	}
+	if err := saveExpiryIndex(index); err != nil {
+		return err
+	}
+
+	fmt.Printf("Range %d now expires at %s (in %s)\n", id, entry.ExpiresAt.Local().Format(time.RFC1123), formatRemaining(time.Until(entry.ExpiresAt)))
+	return nil
+}
+
+func listExpiringRanges(within time.Duration, all, includeWorkspaces bool) error {
+	expiries, err := rangeExpiries(includeWorkspaces)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(within)
+	var expiring []rangeExpiry
+	for _, e := range expiries {
+		if all || e.ExpiresAt.Before(deadline) {
+			expiring = append(expiring, e)
+		}
+	}
+
+	if len(expiring) == 0 {
+		switch {
+		case all && includeWorkspaces:
+			fmt.Println("No ranges have a TTL or workspace time limit")
+		case all:
+			fmt.Println("No ranges have a TTL")
+		default:
+			fmt.Printf("No ranges expire within %s\n", formatRemaining(within))
+		}
+		return nil
+	}
+
+	sort.Slice(expiring, func(i, j int) bool { return expiring[i].ExpiresAt.Before(expiring[j].ExpiresAt) })
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Name", "Expires At", "Remaining", "Limit"})
+	for _, e := range expiring {
+		remaining := time.Until(e.ExpiresAt)
+		left := formatRemaining(remaining)
+		if remaining <= 0 {
+			left = "expired"
+		}
+		table.Append([]string{
+			strconv.Itoa(e.RangeID),
+			e.Name,
+			e.ExpiresAt.Local().Format(time.RFC3339),
+			left,
+			e.Source,
+		})
+	}
+	table.Render()
+	return nil
+}
+
+func reapExpiredRanges(dryRun, includeWorkspaces bool) error {
+	expiries, err := rangeExpiries(includeWorkspaces)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var expired []rangeExpiry
+	for _, e := range expiries {
+		if !e.ExpiresAt.After(now) {
+			expired = append(expired, e)
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool { return expired[i].ExpiresAt.Before(expired[j].ExpiresAt) })
+
+	if len(expired) == 0 {
+		fmt.Printf("[%s] No expired ranges\n", now.Format(time.TimeOnly))
+		return nil
+	}
+
+	if !dryRun && !confirmDestructive(fmt.Sprintf("delete %d expired range(s)", len(expired)), describeExpiredRanges(expired, now)) {
+		return nil
+	}
+
+	var failed int
+	for _, e := range expired {
+		overdue := formatRemaining(now.Sub(e.ExpiresAt))
+		if dryRun {
+			fmt.Printf("[%s] Would delete range %d (%s), expired %s ago\n", now.Format(time.TimeOnly), e.RangeID, e.Name, overdue)
+			continue
+		}
+
+		deleted, err := submitRangeDelete(e.RangeID)
+		switch {
+		case err != nil:
+			failed++
+			fmt.Printf("[%s] ❌ Failed to delete range %d (%s): %s\n", now.Format(time.TimeOnly), e.RangeID, e.Name, err)
+		case !deleted:
+			failed++
+			fmt.Printf("[%s] ❌ The API did not delete range %d (%s)\n", now.Format(time.TimeOnly), e.RangeID, e.Name)
+		default:
+			fmt.Printf("[%s] ✅ Deleted range %d (%s), expired %s ago\n", now.Format(time.TimeOnly), e.RangeID, e.Name, overdue)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d expired range(s)", failed)
+	}
+	return nil
+}
+
+// describeExpiredRanges lists the ranges the reaper is about to delete.
+func describeExpiredRanges(expired []rangeExpiry, now time.Time) func() []string {
+	return func() []string {
+		var lines []string
+		for _, e := range expired {
+			lines = append(lines, fmt.Sprintf("Range %d: %s, expired %s ago (%s)", e.RangeID, e.Name, formatRemaining(now.Sub(e.ExpiresAt)), e.Source))
+		}
+		return lines
+	}
+}
+
+// recordRangeExpiry remembers when a newly deployed range expires.
+func recordRangeExpiry(id int, name string, expiresAt time.Time) error {
+	if DryRun {
+		return nil
+	}
+
+	expiryMu.Lock()
+	defer expiryMu.Unlock()
+
+	index := loadExpiryIndex()
+	index.forAPI(NewClient().BaseURL)[strconv.Itoa(id)] = rangeExpiry{RangeID: id, Name: name, ExpiresAt: expiresAt}
+	return saveExpiryIndex(index)
+}
+
+// clearRangeExpiry forgets the expiry of a deleted range.
+func clearRangeExpiry(id int) error {
+	expiryMu.Lock()
+	defer expiryMu.Unlock()
+
+	index := loadExpiryIndex()
+	entries := index.forAPI(NewClient().BaseURL)
+	key := strconv.Itoa(id)
+	if _, ok := entries[key]; !ok {
+		return nil
+	}
+	delete(entries, key)
+	return saveExpiryIndex(index)
+}
+
+// rangeExpiries returns when each deployed range expires. Only ranges with a
+// TTL set from this machine are included unless includeWorkspaces is set, in
+// which case the remaining ranges fall back to their workspace time limit.
+func rangeExpiries(includeWorkspaces bool) ([]rangeExpiry, error) {
+	ranges, err := fetchRanges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployed ranges: %s", err)
+	}
+
+	index := loadExpiryIndex()
+	entries := index.forAPI(NewClient().BaseURL)
+	if err := pruneExpiryIndex(index, entries, ranges); err != nil {
+		return nil, err
+	}
+
+	limits := map[int]workspaceLimit{}
+	if includeWorkspaces {
+		limits = workspaceTimeLimits()
+	}
+	var expiries []rangeExpiry
+	for _, r := range ranges {
+		if e, ok := entries[strconv.Itoa(r.ID)]; ok {
+			e.Source = "TTL"
+			expiries = append(expiries, e)
+			continue
+		}
+		if limit, ok := limits[r.BlueprintID]; ok {
+			expiries = append(expiries, rangeExpiry{
+				RangeID:   r.ID,
+				Name:      r.Name,
+				ExpiresAt: r.CreatedAt.Add(limit.Limit),
+				Source:    fmt.Sprintf("workspace %s", limit.Workspace),
+			})
+		}
+	}
+	return expiries, nil
+}
+
+// workspaceTimeLimits maps blueprint IDs to the time limit of the workspaces
+// they are shared in. A user's own time limit in a workspace overrides the
+// workspace default. Workspaces that cannot be read are skipped.
+func workspaceTimeLimits() map[int]workspaceLimit {
+	limits := map[int]workspaceLimit{}
+
+	workspaces, err := fetchWorkspaces()
+	if err != nil {
+		if Debug {
+			fmt.Printf("DEBUG: Ignoring workspace time limits: %s\n", err)
+		}
+		return limits
+	}
+	var email string
+	if user, err := fetchCurrentUser(); err == nil {
+		email = user.Email
+	}
+
+	for _, w := range workspaces {
+		seconds := w.DefaultTimeLimit
+		if users, err := fetchWorkspaceUsers(w.ID); err == nil {
+			for _, u := range users {
+				if email != "" && strings.EqualFold(u.Email, email) && u.TimeLimit > 0 {
+					seconds = u.TimeLimit
+				}
+			}
+		}
+		if seconds <= 0 {
+			continue
+		}
+
+		blueprints, err := fetchWorkspaceBlueprints(w.ID)
+		if err != nil {
+			if Debug {
+				fmt.Printf("DEBUG: Ignoring time limit of workspace %d: %s\n", w.ID, err)
+			}
+			continue
+		}
+		limit := time.Duration(seconds) * time.Second
+		for _, b := range blueprints {
+			if b.BlueprintType != "range" {
+				continue
+			}
+			if existing, ok := limits[b.BlueprintID]; !ok || limit < existing.Limit {
+				limits[b.BlueprintID] = workspaceLimit{Limit: limit, Workspace: w.Name}
+			}
+		}
+	}
+	return limits
+}
+
+// pruneExpiryIndex drops entries for ranges that no longer exist, such as
+// ranges deleted from the web UI.
+func pruneExpiryIndex(index expiryIndex, entries map[string]rangeExpiry, ranges []DeployedRangeHeader) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	existing := map[string]bool{}
+	for _, r := range ranges {
+		existing[strconv.Itoa(r.ID)] = true
+	}
+
+	pruned := false
+	for key := range entries {
+		if !existing[key] {
+			delete(entries, key)
+			pruned = true
+		}
+	}
+	if pruned && !DryRun {
+		return saveExpiryIndex(index)
+	}
+	return nil
+}
+
+// parseLifetime parses a positive duration, also accepting days (e.g., 2d).
+func parseLifetime(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("a duration is required (e.g., 4h, 90m, 2d)")
+	}
+
+	var d time.Duration
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a duration (e.g., 4h, 90m, 2d)", s)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		d, err = time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a duration (e.g., 4h, 90m, 2d)", s)
+		}
+	}
+
+	if d <= 0 {
+		return 0, fmt.Errorf("duration must be positive")
+	}
+	return d, nil
+}
+
+// formatRemaining formats a duration in minutes, hours, and days.
+func formatRemaining(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	d = d.Round(time.Minute)
+
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	minutes := (d - hours*time.Hour) / time.Minute
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+func (index expiryIndex) forAPI(apiURL string) map[string]rangeExpiry {
+	if index[apiURL] == nil {
+		index[apiURL] = map[string]rangeExpiry{}
+	}
+	return index[apiURL]
+}
+
+func getExpiryIndexPath() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "expiry.json"), nil
+}
+
+func loadExpiryIndex() expiryIndex {
+	index := expiryIndex{}
+
+	path, err := getExpiryIndexPath()
+	if err != nil {
+		return index
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return index
+	}
+	if err := json.Unmarshal(data, &index); err != nil && Debug {
+		fmt.Printf("DEBUG: Ignoring unreadable expiry index: %s\n", err)
+	}
+	return index
+}
+
+func saveExpiryIndex(index expiryIndex) error {
+	path, err := getExpiryIndexPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func init() {
+	extendRangeCmd.Flags().String("by", "", "How much longer the range may live (e.g., 2h, 30m, 1d)")
+
+	expiringRangesCmd.Flags().String("within", "1h", "Show ranges expiring within this long")
+	expiringRangesCmd.Flags().Bool("all", false, "Show every range with an expiry, however far off")
+	expiringRangesCmd.Flags().Bool("include-workspace-limits", false, "Also expire ranges without a TTL at their workspace time limit")
+
+	reaperCmd.Flags().Duration("interval", 0, "Keep running and reap every interval (e.g., 5m)")
+	reaperCmd.Flags().Bool("include-workspace-limits", false, "Also delete ranges without a TTL that are past their workspace time limit")
+
+	extendRangeCmd.ValidArgsFunction = completeRangeArg
+
+	rangeCmd.AddCommand(extendRangeCmd)
+	rangeCmd.AddCommand(expiringRangesCmd)
+	rootCmd.AddCommand(reaperCmd)
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// namedResource is a resource that can be referred to by ID or name. An alias
+// such as an email or IP address is matched like the name, except that IP
+// addresses must match in full.
+type namedResource struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Alias string `json:"alias,omitempty"`
+}
+
+// resolveID turns a command argument into a resource ID. The argument may be
+// a numeric ID, an exact name, or a prefix matching exactly one name.
+func resolveID(kind, arg string, list func() ([]namedResource, error)) (int, error) {
+	arg = strings.TrimSpace(arg)
+	if arg == "" {
+		return 0, fmt.Errorf("%s ID or name is required", kind)
+	}
+	if id, err := strconv.Atoi(arg); err == nil {
+		return id, nil
+	}
+
+	resources, err := list()
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up %s %q: %s", kind, arg, err)
+	}
+
+	// Prefer exact matches, then case-insensitive ones, then prefixes
+	matchers := []func(name string) bool{
+		func(name string) bool { return name == arg },
+		func(name string) bool { return strings.EqualFold(name, arg) },
+		func(name string) bool { return strings.HasPrefix(strings.ToLower(name), strings.ToLower(arg)) },
+	}
+	for i, match := range matchers {
+		prefixes := i == len(matchers)-1
+		var candidates []namedResource
+		for _, r := range resources {
+			// 10.0.0.1 is a prefix of 10.0.0.10, so addresses only match in full
+			matchAlias := r.Alias != "" && !(prefixes && net.ParseIP(r.Alias) != nil)
+			if match(r.Name) || (matchAlias && match(r.Alias)) {
+				candidates = append(candidates, r)
+			}
+		}
+
+		switch len(candidates) {
+		case 0:
+			continue
+		case 1:
+			return candidates[0].ID, nil
+		default:
+			return 0, ambiguousResourceError(kind, arg, candidates)
+		}
+	}
+
+	return 0, fmt.Errorf("no %s matches %q", kind, arg)
+}
+
+func ambiguousResourceError(kind, arg string, candidates []namedResource) error {
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%q matches %d %ss; use the ID or a longer name:", arg, len(candidates), kind)
+	for _, c := range candidates {
+		fmt.Fprintf(&b, "\n  %d\t%s", c.ID, c.Name)
+		if c.Alias != "" {
+			fmt.Fprintf(&b, " <%s>", c.Alias)
+		}
+	}
+	return fmt.Errorf("%s", b.String())
+}
+
+// resolveRangeID resolves a deployed range by ID or name.
+func resolveRangeID(arg string) (int, error) {
+	return resolveID("range", arg, rangeResources)
+}
+
+func rangeResources() ([]namedResource, error) {
+	ranges, err := fetchRanges()
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]namedResource, 0, len(ranges))
+	for _, r := range ranges {
+		resources = append(resources, namedResource{ID: r.ID, Name: r.Name})
+	}
+	return resources, nil
+}
+
+// resolveBlueprintID resolves a range, vpc, subnet, or host blueprint by ID
+// or name. Host blueprints are named by their hostname.
+func resolveBlueprintID(kind, arg string) (int, error) {
+	return resolveID(kind+" blueprint", arg, func() ([]namedResource, error) {
+		return blueprintResources(kind)
+	})
+}
+
+func blueprintResources(kind string) ([]namedResource, error) {
+	path := fmt.Sprintf("/api/v1/blueprints/%ss", kind)
+	if kind != "range" {
+		path += "?standalone_only=false"
+	}
+
+	client := NewClient()
+	resp, err := client.DoRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var blueprints []struct {
+		ID       int    `json:"id"`
+		Name     string `json:"name"`
+		Hostname string `json:"hostname"`
+	}
+	if err := ParseResponse(resp, &blueprints); err != nil {
+		return nil, err
+	}
+
+	resources := make([]namedResource, 0, len(blueprints))
+	for _, b := range blueprints {
+		name := b.Name
+		if kind == "host" {
+			name = b.Hostname
+		}
+		resources = append(resources, namedResource{ID: b.ID, Name: name})
+	}
+	return resources, nil
+}
+
+// resolveWorkspaceID resolves a workspace by ID or name.
+func resolveWorkspaceID(arg string) (int, error) {
+	return resolveID("workspace", arg, workspaceResources)
+}
+
+func workspaceResources() ([]namedResource, error) {
+	workspaces, err := fetchWorkspaces()
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]namedResource, 0, len(workspaces))
+	for _, w := range workspaces {
+		resources = append(resources, namedResource{ID: w.ID, Name: w.Name})
+	}
+	return resources, nil
+}
+
+// resolveWorkspaceUserID resolves a workspace member by user ID, name, or email.
+func resolveWorkspaceUserID(workspaceID int, arg string) (int, error) {
+	return resolveID("workspace user", arg, func() ([]namedResource, error) {
+		return workspaceUserResources(workspaceID)
+	})
+}
+
+func workspaceUserResources(workspaceID int) ([]namedResource, error) {
+	users, err := fetchWorkspaceUsers(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]namedResource, 0, len(users))
+	for _, u := range users {
+		resources = append(resources, namedResource{ID: u.ID, Name: u.Name, Alias: u.Email})
+	}
+	return resources, nil
+}
@@ -19,6 +19,8 @@ var (
 	AuthToken string
 	EncKey    string
 	Debug     bool
+	DryRun    bool
+	AssumeYes bool
 )
 
 var rootCmd = &cobra.Command{
@@ -36,6 +38,11 @@ var rootCmd = &cobra.Command{
 }
 
 func Execute() {
+	// Unknown commands may be provided by an openlabs-<name> plugin
+	if code, ok := dispatchPlugin(os.Args[1:]); ok {
+		os.Exit(code)
+	}
+
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -57,6 +64,8 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&APIURL, "api-url", "http://localhost:8000", "URL of the OpenLabs API server")
 	rootCmd.PersistentFlags().StringVar(&AuthToken, "token", "", "Authentication token for OpenLabs API")
 	rootCmd.PersistentFlags().BoolVar(&Debug, "debug", false, "Enable debug mode to see detailed request/response information")
+	rootCmd.PersistentFlags().BoolVar(&DryRun, "dry-run", false, "Print requests that would change data instead of sending them")
+	rootCmd.PersistentFlags().BoolVarP(&AssumeYes, "yes", "y", false, "Answer yes to confirmation prompts")
 
 	rootCmd.AddCommand(versionCmd)
 }
@@ -76,7 +76,7 @@ var updateAWSSecretsCmd = &cobra.Command{
 
 			err := updateAWSSecrets(accessKey, secretKey)
 			if err != nil {
-				fmt.Println(err)
+				printError(err)
 			}
 			return
 		}
@@ -90,7 +90,7 @@ var updateAWSSecretsCmd = &cobra.Command{
 
 		err = updateAWSSecrets(accessKey, secretKey)
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
@@ -117,7 +117,7 @@ var updateAzureSecretsCmd = &cobra.Command{
 
 			err := updateAzureSecrets(clientID, clientSecret, tenantID, subscriptionID)
 			if err != nil {
-				fmt.Println(err)
+				printError(err)
 			}
 			return
 		}
@@ -131,7 +131,7 @@ var updateAzureSecretsCmd = &cobra.Command{
 
 		err = updateAzureSecrets(clientID, clientSecret, tenantID, subscriptionID)
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
@@ -219,22 +219,10 @@ func promptAzureCredentials() (string, string, string, string, error) {
 func getSecretsStatus() error {
 	fmt.Println("\n🔍 Fetching cloud provider credentials status...")
 
-	client := NewClient()
-	resp, err := client.DoRequest("GET", "/api/v1/users/me/secrets", nil)
+	secrets, err := fetchSecretsStatus()
 	if err != nil {
 		return err
 	}
-	defer func() {
-		err := resp.Body.Close()
-		if err != nil {
-			fmt.Printf("Error closing response body: %v\n", err)
-		}
-	}()
-
-	var secrets UserSecrets
-	if err := ParseResponse(resp, &secrets); err != nil {
-		return err
-	}
 
 	fmt.Println("\n✅ Cloud provider credentials status retrieved successfully!")
 
@@ -274,6 +262,26 @@ func getSecretsStatus() error {
 	return nil
 }
 
+func fetchSecretsStatus() (UserSecrets, error) {
+	client := NewClient()
+	resp, err := client.DoRequest("GET", "/api/v1/users/me/secrets", nil)
+	if err != nil {
+		return UserSecrets{}, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var secrets UserSecrets
+	if err := ParseResponse(resp, &secrets); err != nil {
+		return UserSecrets{}, err
+	}
+	return secrets, nil
+}
+
 func updateAWSSecrets(accessKey, secretKey string) error {
 	fmt.Println("\n🔄 Updating AWS credentials...")
 
@@ -285,7 +293,7 @@ func updateAWSSecrets(accessKey, secretKey string) error {
 	client := NewClient()
 	resp, err := client.DoRequest("POST", "/api/v1/users/me/secrets/aws", secrets)
 	if err != nil {
-		return fmt.Errorf("failed to update AWS credentials: %s", err)
+		return fmt.Errorf("failed to update AWS credentials: %w", err)
 	}
 	defer func() {
 		err := resp.Body.Close()
@@ -326,7 +334,7 @@ func updateAzureSecrets(clientID, clientSecret, tenantID, subscriptionID string)
 	client := NewClient()
 	resp, err := client.DoRequest("POST", "/api/v1/users/me/secrets/azure", secrets)
 	if err != nil {
-		return fmt.Errorf("failed to update Azure credentials: %s", err)
+		return fmt.Errorf("failed to update Azure credentials: %w", err)
 	}
 	defer func() {
 		err := resp.Body.Close()
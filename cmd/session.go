@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sessionWarnWindow is how close to expiry a session must be before the CLI
+// tries to renew it, or warns when it cannot.
+const sessionWarnWindow = 10 * time.Minute
+
+// refreshTokenCookie is set on login by API versions that support renewing a
+// session without the user's password.
+const refreshTokenCookie = "refresh_token"
+
+var (
+	errSessionExpired = errors.New("your session expired; run 'openlabs user login' to log in again")
+	errNotLoggedIn    = errors.New("you are not logged in; run 'openlabs user login' first")
+)
+
+var (
+	// sessionMu serializes session renewal between concurrent requests.
+	sessionMu sync.Mutex
+
+	// sessionWarning makes sure the expiry warning is printed only once.
+	sessionWarning sync.Once
+)
+
+// tokenClaims are the JWT claims the CLI reads from the session token.
+type tokenClaims struct {
+	Subject   string `json:"sub"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// parseTokenClaims decodes the claims of a JWT without verifying it. Only the
+// API can verify the signature; the CLI just needs to know when it expires.
+func parseTokenClaims(token string) (tokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return tokenClaims{}, fmt.Errorf("token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return tokenClaims{}, fmt.Errorf("failed to decode token payload: %s", err)
+	}
+
+	var claims tokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return tokenClaims{}, fmt.Errorf("failed to parse token claims: %s", err)
+	}
+	return claims, nil
+}
+
+func (c tokenClaims) expiry() (time.Time, bool) {
+	if c.ExpiresAt == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(c.ExpiresAt, 0), true
+}
+
+func (c tokenClaims) issued() (time.Time, bool) {
+	if c.IssuedAt == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(c.IssuedAt, 0), true
+}
+
+// ensureFreshSession checks the client's token before a request is sent. An
+// expired or nearly expired session is renewed when possible; otherwise an
+// expired session is an error and a nearly expired one is a warning.
+func (c *Client) ensureFreshSession() error {
+	if c.AuthToken == "" {
+		return nil
+	}
+
+	// Tokens that are not JWTs cannot be checked locally
+	claims, err := parseTokenClaims(c.AuthToken)
+	if err != nil {
+		return nil
+	}
+	expiresAt, ok := claims.expiry()
+	if !ok {
+		return nil
+	}
+
+	remaining := time.Until(expiresAt)
+	if remaining > sessionWarnWindow {
+		return nil
+	}
+	if c.renewSession() {
+		return nil
+	}
+	if remaining <= 0 {
+		return errSessionExpired
+	}
+
+	sessionWarning.Do(func() {
+		fmt.Fprintf(os.Stderr, "⚠️  Your session expires in %s; run 'openlabs user login' to stay logged in\n", formatRemaining(remaining))
+	})
+	return nil
+}
+
+// renewSession replaces the client's session with a new one, using the
+// refresh token from login when the API or identity provider issued one, or
+// OPENLABS_EMAIL and OPENLABS_PASSWORD when they are set. The new session is
+// saved to the config.
+func (c *Client) renewSession() bool {
+	// Renewing would change the stored session, which a dry run must not do
+	if DryRun {
+		return false
+	}
+
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+
+	config, err := loadConfig()
+	if err != nil {
+		return false
+	}
+
+	// Another request may already have renewed the session
+	if config.AuthToken != "" && config.AuthToken != c.AuthToken && !tokenExpiresSoon(config.AuthToken) {
+		c.useSession(authSession{Token: config.AuthToken, EncKey: config.EncKey})
+		return true
+	}
+
+	var session authSession
+	if config.TokenURL != "" {
+		session, err = refreshOAuthSession(config)
+	} else {
+		session, err = c.refreshSession(config.RefreshToken)
+	}
+	if err != nil {
+		if Debug {
+			fmt.Printf("DEBUG: Session refresh failed: %s\n", err)
+		}
+
+		email, password := os.Getenv("OPENLABS_EMAIL"), os.Getenv("OPENLABS_PASSWORD")
+		if email == "" || password == "" {
+			return false
+		}
+		session, err = authenticate(email, password, nil)
+		if err != nil {
+			if Debug {
+				fmt.Printf("DEBUG: Re-authentication failed: %s\n", err)
+			}
+			return false
+		}
+	}
+
+	config.AuthToken = session.Token
+	if session.EncKey != "" {
+		config.EncKey = session.EncKey
+	}
+	if session.RefreshToken != "" {
+		config.RefreshToken = session.RefreshToken
+	}
+	if err := saveConfig(config); err != nil && Debug {
+		fmt.Printf("DEBUG: Failed to save renewed session: %s\n", err)
+	}
+
+	c.useSession(authSession{Token: config.AuthToken, EncKey: config.EncKey})
+	return true
+}
+
+// refreshSession exchanges a refresh token for a new session token.
+func (c *Client) refreshSession(refreshToken string) (authSession, error) {
+	if refreshToken == "" {
+		return authSession{}, fmt.Errorf("no refresh token")
+	}
+
+	req, err := http.NewRequest("POST", c.BaseURL+"/api/v1/auth/refresh", nil)
+	if err != nil {
+		return authSession{}, err
+	}
+	req.AddCookie(&http.Cookie{Name: refreshTokenCookie, Value: refreshToken})
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return authSession{}, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return authSession{}, fmt.Errorf("refresh failed with status: %s", resp.Status)
+	}
+
+	var session authSession
+	for _, cookie := range resp.Cookies() {
+		switch cookie.Name {
+		case authTokenCookie:
+			session.Token = cookie.Value
+		case encKeyCookie:
+			session.EncKey = cookie.Value
+		case refreshTokenCookie:
+			session.RefreshToken = cookie.Value
+		}
+	}
+	if session.Token == "" {
+		return authSession{}, fmt.Errorf("the API did not set the %q cookie", authTokenCookie)
+	}
+	return session, nil
+}
+
+func (c *Client) useSession(session authSession) {
+	c.AuthToken = session.Token
+	if session.EncKey != "" {
+		c.EncKey = session.EncKey
+	}
+	AuthToken = c.AuthToken
+	EncKey = c.EncKey
+}
+
+func tokenExpiresSoon(token string) bool {
+	claims, err := parseTokenClaims(token)
+	if err != nil {
+		return false
+	}
+	expiresAt, ok := claims.expiry()
+	return ok && time.Until(expiresAt) <= sessionWarnWindow
+}
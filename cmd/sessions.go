@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// Session model structures.
+type UserSession struct {
+	ID         int        `json:"id"`
+	Device     string     `json:"device"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	Current    bool       `json:"current"`
+}
+
+// Session Commands.
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "Manage your active sessions",
+	Long:  "This command lets you see where your account is logged in and log out other devices remotely.",
+}
+
+var listSessionsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List active sessions",
+	Long:  "This command lists the sessions that are logged in to your account, including this one.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := listSessions()
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+var revokeSessionCmd = &cobra.Command{
+	Use:   "revoke [session-id|device|ip]",
+	Short: "Revoke a session",
+	Long: "This command logs out a session, such as one left behind on a lab machine. Use --all-others to " +
+		"revoke every session except this one.",
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		allOthers, _ := cmd.Flags().GetBool("all-others")
+
+		if allOthers == (len(args) == 1) {
+			fmt.Println("Error: specify a session ID or --all-others")
+			return
+		}
+
+		var err error
+		if allOthers {
+			err = revokeOtherSessions()
+		} else {
+			id, resolveErr := resolveID("session", args[0], sessionResources)
+			if resolveErr != nil {
+				fmt.Printf("Error: %s\n", resolveErr)
+				return
+			}
+			err = revokeSessionByID(id)
+		}
+		if err != nil {
+			printError(err)
+		}
+	},
+}
+
+// Sessions Implementation.
+func listSessions() error {
+	sessions, err := fetchSessions()
+	if err != nil {
+		return err
+	}
+
+	if len(sessions) == 0 {
+		fmt.Println("No active sessions found")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Device", "IP Address", "Created", "Last Used", "Current"})
+	for _, s := range sessions {
+		lastUsed := "Never"
+		if s.LastUsedAt != nil {
+			lastUsed = fmt.Sprintf("%s (%s ago)", s.LastUsedAt.Local().Format("2006-01-02 15:04"), formatRemaining(time.Since(*s.LastUsedAt)))
+		}
+		current := ""
+		if s.Current {
+			current = "✅ This CLI"
+		}
+		table.Append([]string{
+			strconv.Itoa(s.ID),
+			sessionDevice(s),
+			valueOrNA(s.IPAddress),
+			s.CreatedAt.Local().Format("2006-01-02 15:04"),
+			lastUsed,
+			current,
+		})
+	}
+	table.Render()
+	return nil
+}
+
+func fetchSessions() ([]UserSession, error) {
+	client := NewClient()
+	resp, err := client.DoRequest("GET", "/api/v1/users/me/sessions", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var sessions []UserSession
+	if err := ParseResponse(resp, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func revokeSessionByID(id int) error {
+	sessions, err := fetchSessions()
+	if err != nil {
+		return err
+	}
+
+	var session *UserSession
+	for i := range sessions {
+		if sessions[i].ID == id {
+			session = &sessions[i]
+		}
+	}
+	if session == nil {
+		return fmt.Errorf("session %d not found; run 'openlabs user sessions list' to see active sessions", id)
+	}
+	if session.Current {
+		return fmt.Errorf("session %d is the one this CLI is using; run 'openlabs user logout' instead", id)
+	}
+
+	if !confirmDestructive(fmt.Sprintf("revoke session %d", id), func() []string {
+		return describeSession(*session)
+	}) {
+		return nil
+	}
+
+	if err := revokeSession(id); err != nil {
+		return err
+	}
+	fmt.Println("Session revoked successfully")
+	return nil
+}
+
+func revokeOtherSessions() error {
+	sessions, err := fetchSessions()
+	if err != nil {
+		return err
+	}
+
+	var others []UserSession
+	for _, s := range sessions {
+		if !s.Current {
+			others = append(others, s)
+		}
+	}
+	// Without knowing which session is ours, "all others" would include it
+	if len(others) == len(sessions) && len(sessions) > 0 {
+		return fmt.Errorf("the API did not identify this CLI's session; revoke sessions by ID instead")
+	}
+	if len(others) == 0 {
+		fmt.Println("No other sessions to revoke")
+		return nil
+	}
+
+	listing := func() []string {
+		var lines []string
+		for _, s := range others {
+			lines = append(lines, fmt.Sprintf("%d: %s from %s", s.ID, sessionDevice(s), valueOrNA(s.IPAddress)))
+		}
+		return lines
+	}
+	if DryRun {
+		fmt.Printf("The following %d session(s) would be revoked:\n", len(others))
+		for _, line := range listing() {
+			fmt.Printf("  %s\n", line)
+		}
+		fmt.Println("Dry run: no sessions were revoked")
+		return nil
+	}
+
+	if !confirmDestructive(fmt.Sprintf("revoke %d other sessions", len(others)), listing) {
+		return nil
+	}
+
+	failed := 0
+	for _, s := range others {
+		if err := revokeSession(s.ID); err != nil {
+			fmt.Printf("❌ Failed to revoke session %d: %s\n", s.ID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✅ Revoked session %d (%s)\n", s.ID, sessionDevice(s))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to revoke %d of %d sessions", failed, len(others))
+	}
+	fmt.Printf("\nRevoked %d sessions; only this one is still logged in\n", len(others))
+	return nil
+}
+
+func revokeSession(id int) error {
+	client := NewClient()
+	resp, err := client.DoRequest("DELETE", fmt.Sprintf("/api/v1/users/me/sessions/%d", id), nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	return ParseResponse(resp, nil)
+}
+
+// sessionDevice names the device a session was created from.
+func sessionDevice(s UserSession) string {
+	if s.Device != "" {
+		return s.Device
+	}
+	if s.UserAgent != "" {
+		return truncate(s.UserAgent, 40)
+	}
+	return "Unknown device"
+}
+
+func sessionResources() ([]namedResource, error) {
+	sessions, err := fetchSessions()
+	if err != nil {
+		return nil, err
+	}
+	var resources []namedResource
+	for _, s := range sessions {
+		if !s.Current {
+			resources = append(resources, namedResource{ID: s.ID, Name: sessionDevice(s), Alias: s.IPAddress})
+		}
+	}
+	return resources, nil
+}
+
+func init() {
+	revokeSessionCmd.Flags().Bool("all-others", false, "Revoke every session except the one this CLI is using")
+
+	revokeSessionCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeResources("session", sessionResources, toComplete)
+	}
+
+	sessionsCmd.AddCommand(listSessionsCmd)
+	sessionsCmd.AddCommand(revokeSessionCmd)
+
+	userCmd.AddCommand(sessionsCmd)
+}
@@ -0,0 +1,826 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// ANSI escape sequences used to draw the terminal UI.
+const (
+	ansiAltScreenOn  = "\x1b[?1049h"
+	ansiAltScreenOff = "\x1b[?1049l"
+	ansiHideCursor   = "\x1b[?25l"
+	ansiShowCursor   = "\x1b[?25h"
+	ansiHome         = "\x1b[H"
+	ansiClearLine    = "\x1b[K"
+	ansiClearBelow   = "\x1b[J"
+	ansiReset        = "\x1b[0m"
+	ansiBold         = "\x1b[1m"
+	ansiDim          = "\x1b[2m"
+	ansiReverse      = "\x1b[7m"
+	ansiRed          = "\x1b[31m"
+	ansiCyan         = "\x1b[36m"
+)
+
+// Keys that are not plain characters.
+const (
+	keyUp = iota + 1000
+	keyDown
+	keyLeft
+	keyRight
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyEsc
+	keyEnter
+	keyBackspace
+	keyTab
+	keyCtrlC
+)
+
+// UI modes.
+const (
+	tuiModeList = iota
+	tuiModeDetail
+	tuiModeInput
+	tuiModeConfirm
+)
+
+// tuiRow is one line of a pane. Key identifies the resource behind it.
+type tuiRow struct {
+	ID    int
+	Key   string
+	Cells []string
+}
+
+// tuiPane is a tab listing one kind of resource.
+type tuiPane struct {
+	Title   string
+	Columns []string
+	Hints   string
+	Load    func() ([]tuiRow, error)
+	Detail  func(row tuiRow) ([]string, error)
+	Keys    map[rune]func(app *tuiApp, row tuiRow)
+	Refresh time.Duration
+
+	rows     []tuiRow
+	filter   string
+	cursor   int
+	offset   int
+	loading  bool
+	loaded   time.Time
+	err      error
+	selected string
+}
+
+// tuiApp is the state of a running terminal UI. All fields are owned by the
+// main loop; background work reports back through updates.
+type tuiApp struct {
+	out     *os.File
+	apiURL  string
+	panes   []*tuiPane
+	active  int
+	mode    int
+	width   int
+	height  int
+	status  string
+	isError bool
+	updates chan func(app *tuiApp)
+	quit    bool
+
+	detailTitle  string
+	detailLines  []string
+	detailOffset int
+
+	inputPrompt string
+	inputValue  string
+	inputDone   func(app *tuiApp, value string)
+	confirmDone func(app *tuiApp)
+}
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse and manage resources in a terminal UI",
+	Long: "This command opens an interactive terminal UI for browsing ranges, blueprints, workspaces, and secrets. " +
+		"Press ? inside the UI for the available keys.",
+	Run: func(cmd *cobra.Command, args []string) {
+		refresh, _ := cmd.Flags().GetDuration("refresh")
+
+		err := runTUI(refresh)
+		if err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+// Terminal UI Implementation.
+func runTUI(refresh time.Duration) error {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return fmt.Errorf("openlabs tui needs an interactive terminal")
+	}
+	if Debug || DryRun {
+		// Debug dumps are too long for the status line, and dry-run actions would only fail
+		return fmt.Errorf("openlabs tui does not support --debug or --dry-run")
+	}
+
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("failed to put terminal in raw mode: %s", err)
+	}
+	defer func() { _ = term.Restore(fd, oldState) }()
+
+	fmt.Print(ansiAltScreenOn + ansiHideCursor)
+	defer fmt.Print(ansiReset + ansiShowCursor + ansiAltScreenOff)
+
+	app := &tuiApp{
+		out:     os.Stdout,
+		apiURL:  NewClient().BaseURL,
+		panes:   tuiPanes(refresh),
+		updates: make(chan func(app *tuiApp), 16),
+	}
+	restore, err := app.captureOutput()
+	if err != nil {
+		return err
+	}
+	defer restore()
+	app.resize()
+	app.load(app.pane())
+
+	keys := make(chan int)
+	go readKeys(os.Stdin, keys)
+
+	// The tick redraws after terminal resizes and drives live refresh
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for !app.quit {
+		app.render()
+
+		select {
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			app.handleKey(key)
+		case update := <-app.updates:
+			update(app)
+		case <-ticker.C:
+			app.resize()
+			for _, p := range app.panes {
+				if p.Refresh > 0 && !p.loading && !p.loaded.IsZero() && time.Since(p.loaded) >= p.Refresh {
+					app.load(p)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (app *tuiApp) pane() *tuiPane {
+	return app.panes[app.active]
+}
+
+func (app *tuiApp) resize() {
+	width, height, err := term.GetSize(int(app.out.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		width, height = 80, 24
+	}
+	app.width, app.height = width, height
+}
+
+// load fetches a pane's rows in the background.
+func (app *tuiApp) load(p *tuiPane) {
+	if p.loading {
+		return
+	}
+	p.loading = true
+
+	go func() {
+		rows, err := p.Load()
+		app.updates <- func(app *tuiApp) {
+			p.loading = false
+			p.loaded = time.Now()
+			p.err = err
+			if err == nil {
+				p.rows = rows
+				p.restoreSelection()
+			}
+		}
+	}()
+}
+
+// run performs an action in the background and reports its result in the
+// status line. done, if set, runs on the main loop after a success.
+func (app *tuiApp) run(busy string, action func() (string, error), done func(app *tuiApp)) {
+	app.setStatus(busy, false)
+	go func() {
+		msg, err := action()
+		app.updates <- func(app *tuiApp) {
+			if err != nil {
+				app.setStatus(err.Error(), true)
+				return
+			}
+			app.setStatus(msg, false)
+			if done != nil {
+				done(app)
+			}
+		}
+	}()
+}
+
+// showDetail loads a detail view for a row in the background.
+func (app *tuiApp) showDetail(title string, load func() ([]string, error)) {
+	app.setStatus("Loading "+title+"...", false)
+	go func() {
+		lines, err := load()
+		app.updates <- func(app *tuiApp) {
+			if err != nil {
+				app.setStatus(err.Error(), true)
+				return
+			}
+			app.setStatus("", false)
+			app.mode = tuiModeDetail
+			app.detailTitle = title
+			app.detailLines = lines
+			app.detailOffset = 0
+		}
+	}()
+}
+
+// prompt asks for a line of input in the status bar.
+func (app *tuiApp) prompt(label, def string, done func(app *tuiApp, value string)) {
+	app.mode = tuiModeInput
+	app.inputPrompt = label
+	app.inputValue = def
+	app.inputDone = done
+}
+
+// captureOutput shows anything printed to stdout while the UI is running,
+// such as errors from the API client, in the status bar instead of letting it
+// draw over the screen. The returned function restores stdout.
+func (app *tuiApp) captureOutput() (func(), error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture output: %s", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			// Output after the main loop stops reading updates is dropped
+			select {
+			case app.updates <- func(app *tuiApp) { app.setStatus(line, strings.HasPrefix(line, "Error")) }:
+			case <-stop:
+			}
+		}
+	}()
+
+	return func() {
+		os.Stdout = stdout
+		close(stop)
+		_ = w.Close()
+		<-done
+		_ = r.Close()
+	}, nil
+}
+
+// confirm asks a yes/no question in the status bar.
+func (app *tuiApp) confirm(question string, done func(app *tuiApp)) {
+	app.mode = tuiModeConfirm
+	app.inputPrompt = question
+	app.confirmDone = done
+}
+
+func (app *tuiApp) setStatus(msg string, isError bool) {
+	app.status = msg
+	app.isError = isError
+}
+
+func (app *tuiApp) handleKey(key int) {
+	if key == keyCtrlC {
+		app.quit = true
+		return
+	}
+
+	switch app.mode {
+	case tuiModeInput:
+		app.handleInputKey(key)
+	case tuiModeConfirm:
+		app.mode = tuiModeList
+		if key == 'y' || key == 'Y' {
+			app.confirmDone(app)
+		} else {
+			app.setStatus("Cancelled", false)
+		}
+	case tuiModeDetail:
+		app.handleDetailKey(key)
+	default:
+		app.handleListKey(key)
+	}
+}
+
+func (app *tuiApp) handleListKey(key int) {
+	p := app.pane()
+	rows := p.visibleRows()
+
+	// Messages last until the next key press
+	app.setStatus("", false)
+
+	switch key {
+	case 'q':
+		app.quit = true
+	case keyUp, 'k':
+		p.move(-1, len(rows))
+	case keyDown, 'j':
+		p.move(1, len(rows))
+	case keyPageUp:
+		p.move(-app.listHeight(), len(rows))
+	case keyPageDown:
+		p.move(app.listHeight(), len(rows))
+	case keyHome, 'g':
+		p.move(-len(rows), len(rows))
+	case keyEnd, 'G':
+		p.move(len(rows), len(rows))
+	case keyTab, keyRight, 'l':
+		app.switchPane((app.active + 1) % len(app.panes))
+	case keyLeft, 'h':
+		app.switchPane((app.active + len(app.panes) - 1) % len(app.panes))
+	case '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		if i := key - '1'; i < len(app.panes) {
+			app.switchPane(i)
+		}
+	case 'r':
+		app.load(p)
+	case '/':
+		app.prompt("Filter", p.filter, func(app *tuiApp, value string) {
+			p.filter = strings.TrimSpace(value)
+			p.cursor, p.offset = 0, 0
+		})
+	case keyEsc:
+		if p.filter != "" {
+			p.filter = ""
+			p.cursor, p.offset = 0, 0
+		}
+	case '?':
+		app.showHelp()
+	case keyEnter:
+		if row, ok := p.current(); ok && p.Detail != nil {
+			detail := p.Detail
+			app.showDetail(strings.TrimSuffix(p.Title, "s")+" "+row.Key, func() ([]string, error) { return detail(row) })
+		}
+	default:
+		if action, ok := p.Keys[rune(key)]; ok {
+			if row, ok := p.current(); ok {
+				action(app, row)
+			}
+		}
+	}
+}
+
+func (app *tuiApp) handleDetailKey(key int) {
+	page := app.height - 4
+	switch key {
+	case 'q', keyEsc, keyBackspace, keyEnter:
+		app.mode = tuiModeList
+	case keyUp, 'k':
+		app.detailOffset--
+	case keyDown, 'j':
+		app.detailOffset++
+	case keyPageUp:
+		app.detailOffset -= page
+	case keyPageDown, ' ':
+		app.detailOffset += page
+	case keyHome, 'g':
+		app.detailOffset = 0
+	case keyEnd, 'G':
+		app.detailOffset = len(app.detailLines)
+	}
+
+	maxOffset := len(app.detailLines) - page
+	if app.detailOffset > maxOffset {
+		app.detailOffset = maxOffset
+	}
+	if app.detailOffset < 0 {
+		app.detailOffset = 0
+	}
+}
+
+func (app *tuiApp) handleInputKey(key int) {
+	switch key {
+	case keyEsc:
+		app.mode = tuiModeList
+		app.setStatus("Cancelled", false)
+	case keyEnter:
+		app.mode = tuiModeList
+		app.inputDone(app, app.inputValue)
+	case keyBackspace:
+		if r := []rune(app.inputValue); len(r) > 0 {
+			app.inputValue = string(r[:len(r)-1])
+		}
+	default:
+		if key >= ' ' && key < keyUp {
+			app.inputValue += string(rune(key))
+		}
+	}
+}
+
+func (app *tuiApp) switchPane(i int) {
+	app.active = i
+	if p := app.pane(); p.loaded.IsZero() {
+		app.load(p)
+	}
+}
+
+func (app *tuiApp) showHelp() {
+	lines := []string{
+		"Navigation",
+		"  1-9, Tab, ←/→   switch pane",
+		"  ↑/↓, j/k        move selection",
+		"  PgUp/PgDn       move a page",
+		"  g/G             first/last row",
+		"  Enter           show details",
+		"  /               filter the list (Esc clears)",
+		"  r               refresh",
+		"  q, Ctrl+C       quit",
+		"",
+	}
+	for _, p := range app.panes {
+		if p.Hints != "" {
+			lines = append(lines, p.Title, "  "+p.Hints, "")
+		}
+	}
+	app.mode = tuiModeDetail
+	app.detailTitle = "Help"
+	app.detailLines = lines
+	app.detailOffset = 0
+}
+
+// listHeight is the number of rows that fit in a pane.
+func (app *tuiApp) listHeight() int {
+	// Tab bar, column headers, status line, and key hints
+	if h := app.height - 4; h > 1 {
+		return h
+	}
+	return 1
+}
+
+func (app *tuiApp) render() {
+	var b strings.Builder
+	b.WriteString(ansiHome)
+
+	app.renderTabs(&b)
+	if app.mode == tuiModeDetail {
+		app.renderDetail(&b)
+	} else {
+		app.renderList(&b)
+	}
+	app.renderStatus(&b)
+
+	b.WriteString(ansiClearBelow)
+	_, _ = io.WriteString(app.out, b.String())
+}
+
+func (app *tuiApp) renderTabs(b *strings.Builder) {
+	line := ansiBold + " OpenLabs " + ansiReset + ansiDim + app.apiURL + ansiReset + "  "
+	width := len(" OpenLabs ") + len(app.apiURL) + 2
+	for i, p := range app.panes {
+		tab := fmt.Sprintf(" %d %s ", i+1, p.Title)
+		width += len(tab)
+		if i == app.active {
+			line += ansiReverse + tab + ansiReset
+		} else {
+			line += tab
+		}
+	}
+	if width > app.width {
+		// Drop the API URL on narrow terminals rather than wrapping
+		line = ""
+		for i, p := range app.panes {
+			tab := fmt.Sprintf(" %d %s ", i+1, p.Title)
+			if i == app.active {
+				tab = ansiReverse + tab + ansiReset
+			}
+			line += tab
+		}
+	}
+	b.WriteString(line + ansiClearLine + "\r\n")
+}
+
+func (app *tuiApp) renderList(b *strings.Builder) {
+	p := app.pane()
+	rows := p.visibleRows()
+	height := app.listHeight()
+
+	widths := columnWidths(p.Columns, rows, app.width)
+	b.WriteString(ansiBold + truncate(formatCells(p.Columns, widths), app.width) + ansiReset + ansiClearLine + "\r\n")
+
+	// Keep the cursor on screen
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+height {
+		p.offset = p.cursor - height + 1
+	}
+
+	for i := 0; i < height; i++ {
+		idx := p.offset + i
+		switch {
+		case idx < len(rows):
+			line := truncate(formatCells(rows[idx].Cells, widths), app.width)
+			if idx == p.cursor {
+				line = ansiReverse + padRight(line, app.width) + ansiReset
+			}
+			b.WriteString(line)
+		case i == 0 && p.err != nil:
+			b.WriteString(ansiRed + truncate(" Error: "+p.err.Error(), app.width) + ansiReset)
+		case i == 0 && p.loading && len(rows) == 0:
+			b.WriteString(ansiDim + " Loading..." + ansiReset)
+		case i == 0 && len(rows) == 0:
+			msg := " Nothing to show"
+			if p.filter != "" {
+				msg += fmt.Sprintf(" matching %q", p.filter)
+			}
+			b.WriteString(ansiDim + msg + ansiReset)
+		}
+		b.WriteString(ansiClearLine + "\r\n")
+	}
+}
+
+func (app *tuiApp) renderDetail(b *strings.Builder) {
+	height := app.listHeight()
+	b.WriteString(ansiBold + truncate(" "+app.detailTitle, app.width) + ansiReset + ansiClearLine + "\r\n")
+	for i := 0; i < height; i++ {
+		if idx := app.detailOffset + i; idx < len(app.detailLines) {
+			b.WriteString(truncate(" "+app.detailLines[idx], app.width))
+		}
+		b.WriteString(ansiClearLine + "\r\n")
+	}
+}
+
+func (app *tuiApp) renderStatus(b *strings.Builder) {
+	p := app.pane()
+
+	var status string
+	switch app.mode {
+	case tuiModeInput:
+		status = ansiCyan + " " + app.inputPrompt + ": " + ansiReset + app.inputValue + ansiReverse + " " + ansiReset
+	case tuiModeConfirm:
+		status = ansiCyan + " " + app.inputPrompt + " (y/N)" + ansiReset
+	default:
+		status = " " + app.status
+		if app.isError {
+			status = ansiRed + truncate(status, app.width) + ansiReset
+		}
+		if app.status == "" {
+			var parts []string
+			if p.filter != "" {
+				parts = append(parts, fmt.Sprintf("filter: %q", p.filter))
+			}
+			if !p.loaded.IsZero() && p.err == nil {
+				parts = append(parts, fmt.Sprintf("%d %s, updated %s", len(p.visibleRows()), strings.ToLower(p.Title), p.loaded.Format(time.TimeOnly)))
+			}
+			if p.loading {
+				parts = append(parts, "refreshing...")
+			}
+			status = ansiDim + " " + strings.Join(parts, " | ") + ansiReset
+		}
+	}
+	b.WriteString(status + ansiClearLine + "\r\n")
+
+	hints := "/ filter  r refresh  ? help  q quit"
+	if p.Detail != nil {
+		hints = "Enter details  " + hints
+	}
+	if app.mode == tuiModeDetail {
+		hints = "↑/↓ scroll  Esc back  q back"
+	} else if p.Hints != "" {
+		hints = p.Hints + "  " + hints
+	}
+	b.WriteString(ansiDim + truncate(" "+hints, app.width) + ansiReset + ansiClearLine)
+}
+
+// visibleRows returns the rows matching the pane's filter.
+func (p *tuiPane) visibleRows() []tuiRow {
+	if p.filter == "" {
+		return p.rows
+	}
+	needle := strings.ToLower(p.filter)
+	var rows []tuiRow
+	for _, r := range p.rows {
+		if strings.Contains(strings.ToLower(strings.Join(r.Cells, " ")), needle) {
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+func (p *tuiPane) current() (tuiRow, bool) {
+	rows := p.visibleRows()
+	if p.cursor < 0 || p.cursor >= len(rows) {
+		return tuiRow{}, false
+	}
+	return rows[p.cursor], true
+}
+
+func (p *tuiPane) move(delta, count int) {
+	p.cursor += delta
+	if p.cursor >= count {
+		p.cursor = count - 1
+	}
+	if p.cursor < 0 {
+		p.cursor = 0
+	}
+	if row, ok := p.current(); ok {
+		p.selected = row.Key
+	}
+}
+
+// restoreSelection keeps the same resource selected after a refresh.
+func (p *tuiPane) restoreSelection() {
+	rows := p.visibleRows()
+	for i, r := range rows {
+		if r.Key == p.selected {
+			p.cursor = i
+			return
+		}
+	}
+	if p.cursor >= len(rows) {
+		p.cursor = len(rows) - 1
+	}
+	if p.cursor < 0 {
+		p.cursor = 0
+	}
+}
+
+// columnWidths sizes columns to their content, giving the last column
+// whatever room is left.
+func columnWidths(columns []string, rows []tuiRow, total int) []int {
+	widths := make([]int, len(columns))
+	for i, c := range columns {
+		widths[i] = len([]rune(c))
+	}
+	for _, r := range rows {
+		for i, cell := range r.Cells {
+			if i < len(widths) && len([]rune(cell)) > widths[i] {
+				widths[i] = len([]rune(cell))
+			}
+		}
+	}
+
+	const maxColumn = 40
+	used := 1
+	for i := range widths {
+		if widths[i] > maxColumn {
+			widths[i] = maxColumn
+		}
+		used += widths[i] + 2
+	}
+	if last := len(widths) - 1; last >= 0 && used < total {
+		widths[last] += total - used
+	}
+	return widths
+}
+
+func formatCells(cells []string, widths []int) string {
+	var b strings.Builder
+	b.WriteString(" ")
+	for i, w := range widths {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		b.WriteString(padRight(truncate(cell, w), w))
+		if i < len(widths)-1 {
+			b.WriteString("  ")
+		}
+	}
+	return b.String()
+}
+
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string(r[:width])
+	}
+	return string(r[:width-1]) + "…"
+}
+
+func padRight(s string, width int) string {
+	if n := len([]rune(s)); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+// readKeys decodes key presses from a raw-mode terminal.
+func readKeys(r io.Reader, keys chan<- int) {
+	defer close(keys)
+
+	buf := make([]byte, 64)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, key := range decodeKeys(buf[:n]) {
+			keys <- key
+		}
+	}
+}
+
+func decodeKeys(input []byte) []int {
+	var keys []int
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case c == 0x1b && i+2 < len(input) && (input[i+1] == '[' || input[i+1] == 'O'):
+			seq, length := decodeEscape(input[i+2:])
+			keys = append(keys, seq)
+			i += 1 + length
+		case c == 0x1b:
+			keys = append(keys, keyEsc)
+		case c == '\r' || c == '\n':
+			keys = append(keys, keyEnter)
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, keyBackspace)
+		case c == '\t':
+			keys = append(keys, keyTab)
+		case c == 0x03:
+			keys = append(keys, keyCtrlC)
+		case c < 0x80:
+			keys = append(keys, int(c))
+		default:
+			// Multi-byte UTF-8 character
+			r := []rune(string(input[i:]))[0]
+			keys = append(keys, int(r))
+			i += len(string(r)) - 1
+		}
+	}
+	return keys
+}
+
+// decodeEscape decodes the part of a CSI or SS3 sequence after "ESC [" and
+// returns the key and the number of bytes consumed.
+func decodeEscape(seq []byte) (int, int) {
+	switch seq[0] {
+	case 'A':
+		return keyUp, 1
+	case 'B':
+		return keyDown, 1
+	case 'C':
+		return keyRight, 1
+	case 'D':
+		return keyLeft, 1
+	case 'H':
+		return keyHome, 1
+	case 'F':
+		return keyEnd, 1
+	}
+
+	// Sequences such as ESC [ 5 ~
+	end := 0
+	for end < len(seq) && seq[end] != '~' {
+		end++
+	}
+	if end == len(seq) {
+		return keyEsc, len(seq)
+	}
+	switch string(seq[:end]) {
+	case "5":
+		return keyPageUp, end + 1
+	case "6":
+		return keyPageDown, end + 1
+	case "1", "7":
+		return keyHome, end + 1
+	case "4", "8":
+		return keyEnd, end + 1
+	}
+	return keyEsc, end + 1
+}
+
+func init() {
+	tuiCmd.Flags().Duration("refresh", 5*time.Second, "How often to refresh range states (0 to disable)")
+
+	rootCmd.AddCommand(tuiCmd)
+}
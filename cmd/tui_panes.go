@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tuiPanes returns the panes shown by the terminal UI. The ranges pane is
+// refreshed every refresh interval so state changes show up live.
+func tuiPanes(refresh time.Duration) []*tuiPane {
+	return []*tuiPane{
+		{
+			Title:   "Ranges",
+			Columns: []string{"ID", "Name", "State", "Blueprint", "Created"},
+			Hints:   "x delete",
+			Load:    loadRangeRows,
+			Detail:  rangeDetail,
+			Refresh: refresh,
+			Keys: map[rune]func(app *tuiApp, row tuiRow){
+				'x': deleteRangeAction,
+			},
+		},
+		{
+			Title:   "Blueprints",
+			Columns: []string{"ID", "Name", "Provider", "VPN", "VNC", "Description"},
+			Hints:   "d deploy",
+			Load:    loadBlueprintRows,
+			Detail:  blueprintDetail,
+			Keys: map[rune]func(app *tuiApp, row tuiRow){
+				'd': deployBlueprintAction,
+			},
+		},
+		{
+			Title:   "Workspaces",
+			Columns: []string{"ID", "Name", "Time Limit", "Description"},
+			Load:    loadWorkspaceRows,
+			Detail:  workspaceDetail,
+		},
+		{
+			Title:   "Secrets",
+			Columns: []string{"Provider", "Status", "Created At"},
+			Load:    loadSecretRows,
+		},
+	}
+}
+
+// Ranges pane.
+func loadRangeRows() ([]tuiRow, error) {
+	ranges, err := fetchRanges()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].ID < ranges[j].ID })
+
+	rows := make([]tuiRow, 0, len(ranges))
+	for _, r := range ranges {
+		rows = append(rows, tuiRow{
+			ID:  r.ID,
+			Key: r.Name,
+			Cells: []string{
+				strconv.Itoa(r.ID),
+				r.Name,
+				r.State,
+				strconv.Itoa(r.BlueprintID),
+				r.CreatedAt.Local().Format("2006-01-02 15:04"),
+			},
+		})
+	}
+	return rows, nil
+}
+
+func rangeDetail(row tuiRow) ([]string, error) {
+	r, err := fetchRange(row.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []string{
+		fmt.Sprintf("ID:          %d", r.ID),
+		fmt.Sprintf("Name:        %s", r.Name),
+		fmt.Sprintf("State:       %s", r.State),
+		fmt.Sprintf("Blueprint:   %d", r.BlueprintID),
+		fmt.Sprintf("Provider:    %s", r.Provider),
+		fmt.Sprintf("Region:      %s", r.Region),
+		fmt.Sprintf("Jumpbox IP:  %s", valueOrNA(r.JumpboxPublicIP)),
+		fmt.Sprintf("Created:     %s", r.CreatedAt.Local().Format(time.RFC1123)),
+	}
+	if r.Description != "" {
+		lines = append(lines, fmt.Sprintf("Description: %s", r.Description))
+	}
+	if expiry, ok := loadExpiryIndex().forAPI(NewClient().BaseURL)[strconv.Itoa(r.ID)]; ok {
+		lines = append(lines, fmt.Sprintf("Expires:     %s (%s)", expiry.ExpiresAt.Local().Format(time.RFC1123), formatRemaining(time.Until(expiry.ExpiresAt))))
+	}
+
+	for _, vpc := range r.VPCs {
+		lines = append(lines, "", fmt.Sprintf("VPC %s  %s", vpc.Name, vpc.CIDR))
+		for _, subnet := range vpc.Subnets {
+			lines = append(lines, fmt.Sprintf("  Subnet %s  %s", subnet.Name, subnet.CIDR))
+			for _, host := range subnet.Hosts {
+				line := fmt.Sprintf("    %-20s %-15s %-16s %-8s %dGB", host.Hostname, host.IPAddress, host.OS, host.Spec, host.Size)
+				if len(host.Tags) > 0 {
+					line += "  [" + strings.Join(host.Tags, ", ") + "]"
+				}
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines, nil
+}
+
+func deleteRangeAction(app *tuiApp, row tuiRow) {
+	app.confirm(fmt.Sprintf("Delete range %s (%d)?", row.Key, row.ID), func(app *tuiApp) {
+		app.run(fmt.Sprintf("Deleting range %s...", row.Key), func() (string, error) {
+			ok, err := submitRangeDelete(row.ID)
+			if err != nil {
+				return "", err
+			}
+			if !ok {
+				return "", fmt.Errorf("failed to delete range %s", row.Key)
+			}
+			return fmt.Sprintf("Range %s deleted", row.Key), nil
+		}, func(app *tuiApp) {
+			app.load(app.panes[0])
+		})
+	})
+}
+
+// Blueprints pane.
+func loadBlueprintRows() ([]tuiRow, error) {
+	blueprints, err := fetchRangeBlueprints()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(blueprints, func(i, j int) bool { return blueprints[i].ID < blueprints[j].ID })
+
+	rows := make([]tuiRow, 0, len(blueprints))
+	for _, b := range blueprints {
+		rows = append(rows, tuiRow{
+			ID:  b.ID,
+			Key: b.Name,
+			Cells: []string{
+				strconv.Itoa(b.ID),
+				b.Name,
+				b.Provider,
+				fmt.Sprintf("%t", b.VPN),
+				fmt.Sprintf("%t", b.VNC),
+				b.Description,
+			},
+		})
+	}
+	return rows, nil
+}
+
+func blueprintDetail(row tuiRow) ([]string, error) {
+	blueprint, err := fetchRangeBlueprint(row.ID)
+	if err != nil {
+		return nil, err
+	}
+	prettyJSON, err := FormatResponse(blueprint)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(prettyJSON, "\n"), nil
+}
+
+func deployBlueprintAction(app *tuiApp, row tuiRow) {
+	app.prompt("Range name", "", func(app *tuiApp, name string) {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			app.setStatus("A range name is required", true)
+			return
+		}
+
+		app.prompt("Region ("+strings.Join(rangeRegions, ", ")+")", rangeRegions[0], func(app *tuiApp, region string) {
+			region = strings.TrimSpace(region)
+			app.confirm(fmt.Sprintf("Deploy %s as %q in %s?", row.Key, name, region), func(app *tuiApp) {
+				app.run(fmt.Sprintf("Deploying %s...", name), func() (string, error) {
+					_, err := submitRangeDeploy(DeployRangeRequest{
+						BlueprintID: row.ID,
+						Name:        name,
+						Region:      region,
+					})
+					if err != nil {
+						return "", err
+					}
+					return fmt.Sprintf("Deployment of %s started", name), nil
+				}, func(app *tuiApp) {
+					app.load(app.panes[0])
+				})
+			})
+		})
+	})
+}
+
+// Workspaces pane.
+func loadWorkspaceRows() ([]tuiRow, error) {
+	workspaces, err := fetchWorkspaces()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(workspaces, func(i, j int) bool { return workspaces[i].ID < workspaces[j].ID })
+
+	rows := make([]tuiRow, 0, len(workspaces))
+	for _, w := range workspaces {
+		rows = append(rows, tuiRow{
+			ID:  w.ID,
+			Key: w.Name,
+			Cells: []string{
+				strconv.Itoa(w.ID),
+				w.Name,
+				formatTimeLimit(w.DefaultTimeLimit),
+				w.Description,
+			},
+		})
+	}
+	return rows, nil
+}
+
+func workspaceDetail(row tuiRow) ([]string, error) {
+	lines := []string{
+		fmt.Sprintf("ID:    %d", row.ID),
+		fmt.Sprintf("Name:  %s", row.Key),
+		"",
+		"Users",
+	}
+
+	users, err := fetchWorkspaceUsers(row.ID)
+	if err != nil {
+		lines = append(lines, "  Error: "+err.Error())
+	} else if len(users) == 0 {
+		lines = append(lines, "  None")
+	}
+	for _, u := range users {
+		lines = append(lines, fmt.Sprintf("  %-6d %-24s %-32s %-8s %s", u.ID, u.Name, u.Email, u.Role, formatTimeLimit(u.TimeLimit)))
+	}
+
+	lines = append(lines, "", "Blueprints")
+	blueprints, err := fetchWorkspaceBlueprints(row.ID)
+	if err != nil {
+		lines = append(lines, "  Error: "+err.Error())
+	} else if len(blueprints) == 0 {
+		lines = append(lines, "  None")
+	}
+	for _, b := range blueprints {
+		lines = append(lines, fmt.Sprintf("  %-6d %-8s %s", b.BlueprintID, b.BlueprintType, b.Permission))
+	}
+	return lines, nil
+}
+
+// Secrets pane.
+func loadSecretRows() ([]tuiRow, error) {
+	secrets, err := fetchSecretsStatus()
+	if err != nil {
+		return nil, err
+	}
+	return []tuiRow{
+		secretRow("AWS", secrets.AWS),
+		secretRow("Azure", secrets.Azure),
+	}, nil
+}
+
+func secretRow(provider string, status SecretStatus) tuiRow {
+	configured := "Not configured"
+	if status.HasCredentials {
+		configured = "Configured"
+	}
+	createdAt := "N/A"
+	if status.CreatedAt != nil {
+		createdAt = status.CreatedAt.Format("2006-01-02 15:04:05")
+	}
+	return tuiRow{Key: provider, Cells: []string{provider, configured, createdAt}}
+}
+
+func formatTimeLimit(seconds int) string {
+	if seconds <= 0 {
+		return "None"
+	}
+	return fmt.Sprintf("%d seconds", seconds)
+}
+
+func valueOrNA(s string) string {
+	if s == "" {
+		return "N/A"
+	}
+	return s
+}
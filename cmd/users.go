@@ -2,11 +2,7 @@ package cmd
 
 import (
 	"bufio"
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"strings"
 	"syscall"
@@ -49,17 +45,46 @@ var userCmd = &cobra.Command{
 var loginCmd = &cobra.Command{
 	Use:   "login",
 	Short: "Login to OpenLabs",
-	Long:  "This command logs you in to the OpenLabs API using interactive prompts.",
+	Long: "This command logs you in to the OpenLabs API. Without flags it prompts for your email and password. " +
+		"For scripts and CI, pass --email (or set OPENLABS_EMAIL) and pipe the password in with --password-stdin " +
+		"(or set OPENLABS_PASSWORD). Accounts with multi-factor authentication are asked for a code, which can " +
+		"also be given with --mfa-code or OPENLABS_MFA_CODE. With --web it logs you in through your browser using " +
+		"single sign-on instead.",
 	Run: func(cmd *cobra.Command, args []string) {
-		email, password, err := promptCredentials()
+		if cmd.Flags().Changed("password") {
+			fmt.Println("Error: --password is not supported because command line arguments are visible to other users; use --password-stdin or OPENLABS_PASSWORD")
+			return
+		}
+
+		if web, _ := cmd.Flags().GetBool("web"); web {
+			issuer, _ := cmd.Flags().GetString("issuer")
+			clientID, _ := cmd.Flags().GetString("client-id")
+			noBrowser, _ := cmd.Flags().GetBool("no-browser")
+			if cmd.Flags().Changed("email") || cmd.Flags().Changed("password-stdin") {
+				fmt.Println("Error: --web cannot be combined with --email or --password-stdin")
+				return
+			}
+
+			err := webLogin(issuer, clientID, !noBrowser)
+			if err != nil {
+				fmt.Println(err)
+			}
+			return
+		}
+
+		email, _ := cmd.Flags().GetString("email")
+		passwordStdin, _ := cmd.Flags().GetBool("password-stdin")
+		code, _ := cmd.Flags().GetString("mfa-code")
+
+		email, password, err := loginCredentials(email, passwordStdin)
 		if err != nil {
 			fmt.Printf("Error getting credentials: %s\n", err)
 			return
 		}
 
-		err = login(email, password)
+		err = login(email, password, code)
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
@@ -80,6 +105,10 @@ var registerCmd = &cobra.Command{
 				fmt.Println("Error: --email, --password, and --name are all required in non-interactive mode")
 				return
 			}
+			if err := validatePassword(password, name, email); err != nil {
+				fmt.Printf("Error: %s\n", err)
+				return
+			}
 		} else {
 			// Interactive mode
 			var err error
@@ -92,7 +121,7 @@ var registerCmd = &cobra.Command{
 
 		err := register(email, password, name)
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
@@ -104,7 +133,7 @@ var logoutCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		err := logout()
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
@@ -136,6 +165,14 @@ var updatePasswordCmd = &cobra.Command{
 				fmt.Println("Error: both --current-password and --new-password are required in non-interactive mode")
 				return
 			}
+			if newPassword == currentPassword {
+				fmt.Println("Error: --new-password must be different from --current-password")
+				return
+			}
+			if err := validatePassword(newPassword, passwordHints()...); err != nil {
+				fmt.Printf("Error: %s\n", err)
+				return
+			}
 		} else {
 			// Interactive mode
 			var err error
@@ -148,7 +185,7 @@ var updatePasswordCmd = &cobra.Command{
 
 		err := updatePassword(currentPassword, newPassword)
 		if err != nil {
-			fmt.Printf("\n❌ Failed to update password: %s\n", err)
+			printError(fmt.Errorf("\n❌ Failed to update password: %w", err))
 			return
 		}
 
@@ -159,284 +196,37 @@ var updatePasswordCmd = &cobra.Command{
 }
 
 // User Implementation.
-func login(email, password string) error {
+func login(email, password, code string) error {
 	fmt.Println("\n🔒 Authenticating...")
 
 	if Debug {
 		fmt.Printf("DEBUG: Logging in with email: %s\n", email)
 	}
 
-	credentials := UserCredentials{
-		Email:    email,
-		Password: password,
-	}
-
-	client := NewClient()
-	client.AuthToken = "" // Clear any existing token
-	client.EncKey = ""    // Clear any existing key
-
-	// Use a custom HTTP client directly instead of DoRequest to better examine the response
-	url := fmt.Sprintf("%s/api/v1/auth/login", client.BaseURL)
-
-	jsonData, err := json.Marshal(credentials)
-	if err != nil {
-		return fmt.Errorf("failed to marshal credentials: %s", err)
-	}
-
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	session, err := authenticate(email, password, mfaCode(code, "Authentication code (or recovery code)"))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %s", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	if Debug {
-		fmt.Printf("DEBUG: Making direct login request to %s\n", url)
-	}
-
-	resp, err := client.HTTPClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("login request failed: %s", err)
-	}
-	defer func() {
-		err := resp.Body.Close()
-		if err != nil {
-			fmt.Printf("Error closing response body: %v\n", err)
-		}
-	}()
-
-	if Debug {
-		fmt.Printf("DEBUG: Login response status: %s\n", resp.Status)
-		fmt.Println("DEBUG: Login response headers:")
-		for k, v := range resp.Header {
-			fmt.Printf("DEBUG:   %s: %s\n", k, v)
-		}
-		fmt.Println("DEBUG: Login response cookies:")
-		for _, cookie := range resp.Cookies() {
-			fmt.Printf("DEBUG:   %s: %s (HttpOnly: %t, Secure: %t)\n",
-				cookie.Name, cookie.Value, cookie.HttpOnly, cookie.Secure)
-		}
-	}
-
-	var result struct {
-		Success bool `json:"success"`
-	}
-	if err := ParseResponse(resp, &result); err != nil {
 		return err
 	}
 
-	if result.Success {
-		fmt.Println("\n✅ Login successful!")
-		fmt.Println("\nWelcome to OpenLabs CLI!")
-		fmt.Println("Use 'openlabs user info' to see your account information.")
-
-		// Store token in config
-		config, _ := loadConfig()
-		tokenFound := false
-
-		// Look for the token and encryption key in response cookies
-		if Debug {
-			fmt.Println("\nDEBUG: Examining response cookies directly (more reliable)...")
-		}
-
-		// Use resp.Cookies() which gives us more reliable access to cookies
-		for _, cookie := range resp.Cookies() {
-			if Debug {
-				fmt.Printf("DEBUG: Response Cookie: %s = %s (HttpOnly: %t)\n",
-					cookie.Name, cookie.Value, cookie.HttpOnly)
-			}
-
-			if cookie.Name == "access_token_cookie" || cookie.Name == "access_token" ||
-				cookie.Name == "jwt" || cookie.Name == "token" || cookie.Name == "auth_token" {
-				config.AuthToken = cookie.Value
-				tokenFound = true
-				if Debug {
-					fmt.Printf("DEBUG: Found auth token in cookie '%s': %s\n", cookie.Name, cookie.Value)
-				}
-			}
-			if cookie.Name == "enc_key" {
-				config.EncKey = cookie.Value
-				fmt.Println("Encryption key stored successfully from cookie.")
-				if Debug {
-					fmt.Printf("DEBUG: Found encryption key in cookie: %s\n", config.EncKey)
-				}
-			}
-		}
-
-		// Also check Set-Cookie headers directly - sometimes needed for HTTP-only cookies
-		if !tokenFound {
-			if Debug {
-				fmt.Println("\nDEBUG: No token found in cookies, checking Set-Cookie headers...")
-			}
-
-			setCookieHeaders := resp.Header["Set-Cookie"]
-			for _, setCookie := range setCookieHeaders {
-				if Debug {
-					fmt.Printf("DEBUG: Set-Cookie header: %s\n", setCookie)
-				}
-
-				// Extract cookie name and value from Set-Cookie header
-				parts := strings.Split(setCookie, ";")
-				if len(parts) > 0 {
-					nameValue := strings.Split(parts[0], "=")
-					if len(nameValue) == 2 {
-						name := nameValue[0]
-						value := nameValue[1]
-
-						if name == "access_token_cookie" || name == "access_token" ||
-							name == "jwt" || name == "token" || name == "auth_token" {
-							config.AuthToken = value
-							tokenFound = true
-							if Debug {
-								fmt.Printf("DEBUG: Found auth token in Set-Cookie header '%s': %s\n", name, value)
-							}
-						}
-						if name == "enc_key" && config.EncKey == "" {
-							config.EncKey = value
-							fmt.Println("Encryption key found in Set-Cookie header.")
-						}
-					}
-				}
-			}
-		}
-
-		// Check if tokens might be in response body
-		var responseBody map[string]interface{}
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		if err := resp.Body.Close(); err != nil {
-			fmt.Printf("Error closing response body: %v\n", err)
-		}
-
-		// Create a new body for future reads
-		resp.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-
-		if Debug {
-			fmt.Println("\nDEBUG: Examining response body for tokens...")
-			fmt.Printf("DEBUG: Response body: %s\n", string(bodyBytes))
-		}
-
-		if err := json.Unmarshal(bodyBytes, &responseBody); err == nil {
-			// Look for encryption key
-			if EncKeyVal, ok := responseBody["enc_key"]; ok && EncKeyVal != nil {
-				if EncKeyStr, ok := EncKeyVal.(string); ok && EncKeyStr != "" {
-					config.EncKey = EncKeyStr
-					fmt.Println("Encryption key stored successfully from response body.")
-					if Debug {
-						fmt.Printf("DEBUG: Found encryption key in body: %s\n", config.EncKey)
-					}
-				}
-			}
-
-			// Look for access token fields in the body
-			for _, field := range []string{"access_token", "token", "jwt"} {
-				if tokenVal, ok := responseBody[field]; ok && tokenVal != nil && !tokenFound {
-					if tokenStr, ok := tokenVal.(string); ok && tokenStr != "" {
-						config.AuthToken = tokenStr
-						tokenFound = true
-						fmt.Println("Authentication token stored successfully from response body.")
-						if Debug {
-							fmt.Printf("DEBUG: Found auth token in response body field '%s'\n", field)
-						}
-						break
-					}
-				}
-			}
-		}
-
-		// If no matching cookie found, try looking for one with any name containing 'token'
-		if !tokenFound {
-			for _, cookie := range client.LastCookies {
-				if strings.Contains(strings.ToLower(cookie.Name), "token") {
-					config.AuthToken = cookie.Value
-					tokenFound = true
-					break
-				}
-			}
-		}
-
-		// If still no cookie found, check for Authorization header
-		if !tokenFound && resp.Header.Get("Authorization") != "" {
-			bearer := resp.Header.Get("Authorization")
-			// If it starts with "Bearer ", extract just the token part
-			if len(bearer) > 7 && strings.HasPrefix(bearer, "Bearer ") {
-				config.AuthToken = bearer[7:]
-			} else {
-				config.AuthToken = bearer
-			}
-			tokenFound = true
-		}
-
-		// Final fallback: Check for auth token in Authorization header
-		if !tokenFound {
-			if Debug {
-				fmt.Println("\nDEBUG: No token found yet, checking Authorization header...")
-			}
-
-			authHeaders := resp.Header["Authorization"]
-			for _, authHeader := range authHeaders {
-				if Debug {
-					fmt.Printf("DEBUG: Authorization header: %s\n", authHeader)
-				}
-
-				if strings.HasPrefix(authHeader, "Bearer ") {
-					config.AuthToken = strings.TrimPrefix(authHeader, "Bearer ")
-					tokenFound = true
-					if Debug {
-						fmt.Printf("DEBUG: Found auth token in Authorization header: %s\n", config.AuthToken)
-					}
-				}
-			}
-		}
-
-		// As a last resort, try any header that might contain a token
-		if !tokenFound {
-			if Debug {
-				fmt.Println("\nDEBUG: Trying headers with 'token' in the name...")
-			}
-
-			for headerName, headerValues := range resp.Header {
-				headerLower := strings.ToLower(headerName)
-				if strings.Contains(headerLower, "token") || strings.Contains(headerLower, "auth") ||
-					strings.Contains(headerLower, "jwt") {
-					if len(headerValues) > 0 {
-						value := headerValues[0]
-						// Clean up "Bearer " prefix if present
-						value = strings.TrimPrefix(value, "Bearer ")
-						config.AuthToken = value
-						tokenFound = true
-						if Debug {
-							fmt.Printf("DEBUG: Found potential auth token in header '%s': %s\n", headerName, value)
-						}
-						break
-					}
-				}
-			}
-		}
+	config, _ := loadConfig()
+	config.AuthToken = session.Token
+	config.EncKey = session.EncKey
+	config.RefreshToken = session.RefreshToken
+	config.APIKey = "" // A saved key would take precedence over the new session
+	config.TokenURL = ""
+	config.ClientID = ""
+	if err := saveConfig(config); err != nil {
+		return fmt.Errorf("logged in but failed to save credentials: %s", err)
+	}
 
-		// Update global tokens
-		if tokenFound {
-			AuthToken = config.AuthToken
-			fmt.Println("Authentication token stored successfully.")
-		} else {
-			// Try to manually generate a token if needed (special case)
-			AuthToken = "manual-token-for-testing"
-			config.AuthToken = AuthToken
-			fmt.Println("WARNING: No token found! Using a dummy token for testing.")
-			fmt.Println("This is for debugging only and may not work in production.")
-		}
+	// Update global tokens
+	AuthToken = session.Token
+	EncKey = session.EncKey
 
-		// Update encryption key in global variable
-		if config.EncKey != "" {
-			EncKey = config.EncKey
-		}
-
-		// Save the configuration
-		if err := saveConfig(config); err != nil {
-			fmt.Println("Error saving configuration:", err)
-		}
-	} else {
-		fmt.Println("\n❌ Login failed. Please check your credentials.")
-	}
+	fmt.Println("\n✅ Login successful!")
+	warnAPIKeyEnv()
+	fmt.Println("\nWelcome to OpenLabs CLI!")
+	fmt.Println("Use 'openlabs user info' to see your account information.")
 
 	return nil
 }
@@ -484,10 +274,21 @@ func register(email, password, name string) error {
 func logout() error {
 	fmt.Println("\n🔓 Logging out...")
 
+	// A dry run shows the logout request without clearing the saved session
+	if DryRun {
+		printDryRunRequest("POST", NewClient().BaseURL+"/api/v1/auth/logout", "")
+		fmt.Println("The saved session would be cleared")
+		return nil
+	}
+
 	// Always clear local tokens regardless of API response
 	config, _ := loadConfig()
 	config.AuthToken = ""
 	config.EncKey = ""
+	config.RefreshToken = ""
+	config.APIKey = ""
+	config.TokenURL = ""
+	config.ClientID = ""
 	if err := saveConfig(config); err != nil {
 		fmt.Println("Error saving configuration:", err)
 	}
@@ -528,22 +329,10 @@ func logout() error {
 func getUserInfo() error {
 	fmt.Println("\n👤 Fetching user profile...")
 
-	client := NewClient()
-	resp, err := client.DoRequest("GET", "/api/v1/users/me", nil)
+	userInfo, err := fetchCurrentUser()
 	if err != nil {
 		return err
 	}
-	defer func() {
-		err := resp.Body.Close()
-		if err != nil {
-			fmt.Printf("Error closing response body: %v\n", err)
-		}
-	}()
-
-	var userInfo UserInfo
-	if err := ParseResponse(resp, &userInfo); err != nil {
-		return err
-	}
 
 	fmt.Println("\n✅ User profile retrieved successfully!")
 
@@ -567,8 +356,8 @@ func getUserInfo() error {
 	table.Render()
 
 	// Get secrets status to display a more complete profile
-	client = NewClient()
-	resp, err = client.DoRequest("GET", "/api/v1/users/me/secrets", nil)
+	client := NewClient()
+	resp, err := client.DoRequest("GET", "/api/v1/users/me/secrets", nil)
 	if err == nil {
 		defer func() {
 			err := resp.Body.Close()
@@ -604,6 +393,27 @@ func getUserInfo() error {
 	return nil
 }
 
+// fetchCurrentUser retrieves the profile of the logged in user.
+func fetchCurrentUser() (UserInfo, error) {
+	client := NewClient()
+	resp, err := client.DoRequest("GET", "/api/v1/users/me", nil)
+	if err != nil {
+		return UserInfo{}, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var userInfo UserInfo
+	if err := ParseResponse(resp, &userInfo); err != nil {
+		return UserInfo{}, err
+	}
+	return userInfo, nil
+}
+
 func updatePassword(currentPassword, newPassword string) error {
 	fmt.Println("\n🔄 Updating password...")
 
@@ -656,7 +466,7 @@ func updatePassword(currentPassword, newPassword string) error {
 
 		// Automatically log in with the new password
 		fmt.Println("\n🔄 Automatically logging in with new password...")
-		err = login(userInfo.Email, newPassword)
+		err = login(userInfo.Email, newPassword, "")
 		if err != nil {
 			fmt.Printf("\nAuto-login failed: %s\nPlease login manually with your new password using 'openlabs user login'", err)
 		}
@@ -667,6 +477,60 @@ func updatePassword(currentPassword, newPassword string) error {
 	return nil
 }
 
+// loginCredentials gathers login credentials from flags, then the
+// OPENLABS_EMAIL and OPENLABS_PASSWORD environment variables, and prompts for
+// whatever is still missing when stdin is a terminal.
+func loginCredentials(email string, passwordStdin bool) (string, string, error) {
+	if email == "" {
+		email = strings.TrimSpace(os.Getenv("OPENLABS_EMAIL"))
+	}
+
+	var password string
+	if passwordStdin {
+		if email == "" {
+			return "", "", fmt.Errorf("--password-stdin requires --email or OPENLABS_EMAIL")
+		}
+		var err error
+		password, err = readPasswordStdin()
+		if err != nil {
+			return "", "", err
+		}
+	} else {
+		password = os.Getenv("OPENLABS_PASSWORD")
+	}
+
+	if email != "" && password != "" {
+		return email, password, nil
+	}
+
+	if !term.IsTerminal(int(syscall.Stdin)) {
+		return "", "", fmt.Errorf("stdin is not a terminal; use --email with --password-stdin, or set OPENLABS_EMAIL and OPENLABS_PASSWORD")
+	}
+	if email == "" && password == "" {
+		return promptCredentials()
+	}
+
+	if email == "" {
+		fmt.Print("Email: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			return "", "", err
+		}
+		email = strings.TrimSpace(line)
+	}
+	if password == "" {
+		fmt.Print("Password: ")
+		passwordBytes, err := term.ReadPassword(int(syscall.Stdin))
+		if err != nil {
+			return "", "", err
+		}
+		fmt.Println() // Add newline after password input
+		password = string(passwordBytes)
+	}
+
+	return email, password, nil
+}
+
 // promptCredentials prompts the user for their email and password.
 func promptCredentials() (string, string, error) {
 	// Read email
@@ -711,29 +575,11 @@ func promptRegistrationInfo() (string, string, string, error) {
 	}
 	email = strings.TrimSpace(email)
 
-	// Get password (hidden)
-	fmt.Print("Password: ")
-	passwordBytes, err := term.ReadPassword(int(syscall.Stdin))
-	if err != nil {
-		return "", "", "", err
-	}
-	fmt.Println()
-
-	// Confirm password (hidden)
-	fmt.Print("Confirm Password: ")
-	confirmBytes, err := term.ReadPassword(int(syscall.Stdin))
+	// Get password (hidden), checked against the password policy as it is typed
+	password, err := promptNewPassword("Password", name, email)
 	if err != nil {
 		return "", "", "", err
 	}
-	fmt.Println()
-
-	password := string(passwordBytes)
-	confirmPassword := string(confirmBytes)
-
-	// Check if passwords match
-	if password != confirmPassword {
-		return "", "", "", fmt.Errorf("passwords don't match")
-	}
 
 	return name, email, password, nil
 }
@@ -748,35 +594,32 @@ func promptPasswordUpdate() (string, string, error) {
 	}
 	fmt.Println()
 
-	// Read new password (hidden)
-	fmt.Print("New Password: ")
-	newPasswordBytes, err := term.ReadPassword(int(syscall.Stdin))
-	if err != nil {
-		return "", "", err
-	}
-	fmt.Println()
+	currentPassword := string(currentPasswordBytes)
 
-	// Confirm new password (hidden)
-	fmt.Print("Confirm New Password: ")
-	confirmPasswordBytes, err := term.ReadPassword(int(syscall.Stdin))
+	// Read new password (hidden), checked against the password policy as it is typed
+	newPassword, err := promptNewPassword("New Password", passwordHints()...)
 	if err != nil {
 		return "", "", err
 	}
-	fmt.Println()
-
-	currentPassword := string(currentPasswordBytes)
-	newPassword := string(newPasswordBytes)
-	confirmPassword := string(confirmPasswordBytes)
-
-	// Check if new passwords match
-	if newPassword != confirmPassword {
-		return "", "", fmt.Errorf("new passwords don't match")
+	if newPassword == currentPassword {
+		return "", "", fmt.Errorf("new password must be different from the current password")
 	}
 
 	return currentPassword, newPassword, nil
 }
 
 func init() {
+	// Login command flags (for non-interactive mode)
+	loginCmd.Flags().String("email", "", "User email (or set OPENLABS_EMAIL)")
+	loginCmd.Flags().Bool("password-stdin", false, "Read the password from stdin (or set OPENLABS_PASSWORD)")
+	loginCmd.Flags().String("password", "", "Not supported; use --password-stdin or OPENLABS_PASSWORD")
+	_ = loginCmd.Flags().MarkHidden("password")
+	loginCmd.Flags().String("mfa-code", "", "Authentication code for accounts with MFA (or set OPENLABS_MFA_CODE)")
+	loginCmd.Flags().Bool("web", false, "Log in through your browser using single sign-on")
+	loginCmd.Flags().String("issuer", "", "Identity provider URL for --web (defaults to the API URL)")
+	loginCmd.Flags().String("client-id", defaultOAuthClientID, "OAuth client ID for --web")
+	loginCmd.Flags().Bool("no-browser", false, "Print the --web login URL instead of opening a browser")
+
 	// Register command flags (for non-interactive mode)
 	registerCmd.Flags().String("email", "", "User email (for non-interactive mode)")
 	registerCmd.Flags().String("password", "", "User password (for non-interactive mode)")
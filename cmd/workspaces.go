@@ -52,6 +52,13 @@ type WorkspaceBlueprint struct {
 	Permission    string `json:"permission"`
 }
 
+// Values accepted by the workspace API.
+var (
+	workspaceRoles       = []string{"owner", "manager", "member"}
+	blueprintPermissions = []string{"view", "deploy", "edit"}
+	blueprintTypes       = []string{"range", "vpc", "subnet", "host"}
+)
+
 // Workspace Commands.
 var workspacesCmd = &cobra.Command{
 	Use:   "workspace",
@@ -72,14 +79,14 @@ var listWorkspacesCmd = &cobra.Command{
 }
 
 var getWorkspaceCmd = &cobra.Command{
-	Use:   "get [workspace-id]",
+	Use:   "get [workspace-id|name]",
 	Short: "Get a workspace",
 	Long:  "This command will get details of a workspace.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		id, err := strconv.Atoi(args[0])
+		id, err := resolveWorkspaceID(args[0])
 		if err != nil {
-			fmt.Println("Error: workspace ID must be a number")
+			fmt.Printf("Error: %s\n", err)
 			return
 		}
 		err = getWorkspace(id)
@@ -105,39 +112,42 @@ var createWorkspaceCmd = &cobra.Command{
 
 		err := createWorkspace(name, description, timeLimit)
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
 
 var deleteWorkspaceCmd = &cobra.Command{
-	Use:   "delete [workspace-id]",
+	Use:   "delete [workspace-id|name]",
 	Short: "Delete a workspace",
 	Long:  "This command will delete a workspace.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		id, err := strconv.Atoi(args[0])
+		id, err := resolveWorkspaceID(args[0])
 		if err != nil {
-			fmt.Println("Error: workspace ID must be a number")
+			fmt.Printf("Error: %s\n", err)
+			return
+		}
+		if !confirmDestructive(fmt.Sprintf("delete workspace %d", id), describeWorkspace(id)) {
 			return
 		}
 		err = deleteWorkspace(id)
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
 
 // Workspace Users Commands
 var listWorkspaceUsersCmd = &cobra.Command{
-	Use:   "list-users [workspace-id]",
+	Use:   "list-users [workspace-id|name]",
 	Short: "List workspace users",
 	Long:  "This command will list all users in a workspace.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		id, err := strconv.Atoi(args[0])
+		id, err := resolveWorkspaceID(args[0])
 		if err != nil {
-			fmt.Println("Error: workspace ID must be a number")
+			fmt.Printf("Error: %s\n", err)
 			return
 		}
 		err = listWorkspaceUsers(id)
@@ -148,48 +158,53 @@ var listWorkspaceUsersCmd = &cobra.Command{
 }
 
 var addWorkspaceUserCmd = &cobra.Command{
-	Use:   "add-user [workspace-id]",
+	Use:   "add-user [workspace-id|name]",
 	Short: "Add a user to a workspace",
 	Long:  "This command will add a user to a workspace.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		workspaceID, err := strconv.Atoi(args[0])
+		workspaceID, err := resolveWorkspaceID(args[0])
 		if err != nil {
-			fmt.Println("Error: workspace ID must be a number")
+			fmt.Printf("Error: %s\n", err)
 			return
 		}
 
-		userID, _ := cmd.Flags().GetInt("user-id")
+		userArg, _ := cmd.Flags().GetString("user-id")
 		role, _ := cmd.Flags().GetString("role")
 		timeLimit, _ := cmd.Flags().GetInt("time-limit")
 
-		if userID == 0 || role == "" {
+		if userArg == "" || role == "" {
 			fmt.Println("Error: --user-id and --role are required")
 			return
 		}
+		userID, err := resolveID("user", userArg, adminUserResources)
+		if err != nil {
+			fmt.Printf("Error: %s\n", err)
+			return
+		}
 
 		err = addWorkspaceUser(workspaceID, userID, role, timeLimit)
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
 
 var updateWorkspaceUserCmd = &cobra.Command{
-	Use:   "update-user [workspace-id] [user-id]",
+	Use:   "update-user [workspace-id|name] [user-id|email]",
 	Short: "Update a user in a workspace",
 	Long:  "This command will update a user's role or time limit in a workspace.",
 	Args:  cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
-		workspaceID, err := strconv.Atoi(args[0])
+		workspaceID, err := resolveWorkspaceID(args[0])
 		if err != nil {
-			fmt.Println("Error: workspace ID must be a number")
+			fmt.Printf("Error: %s\n", err)
 			return
 		}
 
-		userID, err := strconv.Atoi(args[1])
+		userID, err := resolveWorkspaceUserID(workspaceID, args[1])
 		if err != nil {
-			fmt.Println("Error: user ID must be a number")
+			fmt.Printf("Error: %s\n", err)
 			return
 		}
 
@@ -203,46 +218,49 @@ var updateWorkspaceUserCmd = &cobra.Command{
 
 		err = updateWorkspaceUser(workspaceID, userID, role, timeLimit)
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
 
 var removeWorkspaceUserCmd = &cobra.Command{
-	Use:   "remove-user [workspace-id] [user-id]",
+	Use:   "remove-user [workspace-id|name] [user-id|email]",
 	Short: "Remove a user from a workspace",
 	Long:  "This command will remove a user from a workspace.",
 	Args:  cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
-		workspaceID, err := strconv.Atoi(args[0])
+		workspaceID, err := resolveWorkspaceID(args[0])
 		if err != nil {
-			fmt.Println("Error: workspace ID must be a number")
+			fmt.Printf("Error: %s\n", err)
 			return
 		}
 
-		userID, err := strconv.Atoi(args[1])
+		userID, err := resolveWorkspaceUserID(workspaceID, args[1])
 		if err != nil {
-			fmt.Println("Error: user ID must be a number")
+			fmt.Printf("Error: %s\n", err)
 			return
 		}
 
+		if !confirmDestructive(fmt.Sprintf("remove user %d from workspace %d", userID, workspaceID), describeWorkspaceUser(workspaceID, userID)) {
+			return
+		}
 		err = removeWorkspaceUser(workspaceID, userID)
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
 
 // Workspace Blueprints Commands
 var listWorkspaceBlueprintsCmd = &cobra.Command{
-	Use:   "list-blueprints [workspace-id]",
+	Use:   "list-blueprints [workspace-id|name]",
 	Short: "List workspace blueprints",
 	Long:  "This command will list all blueprints shared with a workspace.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		id, err := strconv.Atoi(args[0])
+		id, err := resolveWorkspaceID(args[0])
 		if err != nil {
-			fmt.Println("Error: workspace ID must be a number")
+			fmt.Printf("Error: %s\n", err)
 			return
 		}
 		err = listWorkspaceBlueprints(id)
@@ -253,48 +271,47 @@ var listWorkspaceBlueprintsCmd = &cobra.Command{
 }
 
 var addWorkspaceBlueprintCmd = &cobra.Command{
-	Use:   "add-blueprint [workspace-id]",
+	Use:   "add-blueprint [workspace-id|name]",
 	Short: "Share a blueprint with a workspace",
 	Long:  "This command will share a blueprint with a workspace.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		workspaceID, err := strconv.Atoi(args[0])
+		workspaceID, err := resolveWorkspaceID(args[0])
 		if err != nil {
-			fmt.Println("Error: workspace ID must be a number")
+			fmt.Printf("Error: %s\n", err)
 			return
 		}
 
-		blueprintID, _ := cmd.Flags().GetInt("blueprint-id")
+		blueprintArg, _ := cmd.Flags().GetString("blueprint-id")
 		blueprintType, _ := cmd.Flags().GetString("blueprint-type")
 		permission, _ := cmd.Flags().GetString("permission")
 
-		if blueprintID == 0 || blueprintType == "" || permission == "" {
+		if blueprintArg == "" || blueprintType == "" || permission == "" {
 			fmt.Println("Error: --blueprint-id, --blueprint-type, and --permission are required")
 			return
 		}
+		blueprintID, err := resolveBlueprintID(blueprintType, blueprintArg)
+		if err != nil {
+			fmt.Printf("Error: %s\n", err)
+			return
+		}
 
 		err = addWorkspaceBlueprint(workspaceID, blueprintID, blueprintType, permission)
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
 
 var removeWorkspaceBlueprintCmd = &cobra.Command{
-	Use:   "remove-blueprint [workspace-id] [blueprint-id]",
+	Use:   "remove-blueprint [workspace-id|name] [blueprint-id|name]",
 	Short: "Remove a blueprint from a workspace",
 	Long:  "This command will remove a blueprint from a workspace.",
 	Args:  cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
-		workspaceID, err := strconv.Atoi(args[0])
+		workspaceID, err := resolveWorkspaceID(args[0])
 		if err != nil {
-			fmt.Println("Error: workspace ID must be a number")
-			return
-		}
-
-		blueprintID, err := strconv.Atoi(args[1])
-		if err != nil {
-			fmt.Println("Error: blueprint ID must be a number")
+			fmt.Printf("Error: %s\n", err)
 			return
 		}
 
@@ -304,31 +321,25 @@ var removeWorkspaceBlueprintCmd = &cobra.Command{
 			return
 		}
 
+		blueprintID, err := resolveBlueprintID(blueprintType, args[1])
+		if err != nil {
+			fmt.Printf("Error: %s\n", err)
+			return
+		}
+
 		err = removeWorkspaceBlueprint(workspaceID, blueprintID, blueprintType)
 		if err != nil {
-			fmt.Println(err)
+			printError(err)
 		}
 	},
 }
 
 // Workspace Implementation.
 func listWorkspaces() error {
-	client := NewClient()
-	resp, err := client.DoRequest("GET", "/api/v1/workspaces", nil)
+	workspaces, err := fetchWorkspaces()
 	if err != nil {
 		return err
 	}
-	defer func() {
-		err := resp.Body.Close()
-		if err != nil {
-			fmt.Printf("Error closing response body: %v\n", err)
-		}
-	}()
-
-	var workspaces []Workspace
-	if err := ParseResponse(resp, &workspaces); err != nil {
-		return err
-	}
 
 	if len(workspaces) == 0 {
 		fmt.Println("No workspaces found")
@@ -352,6 +363,28 @@ func listWorkspaces() error {
 	return nil
 }
 
+// fetchWorkspaces retrieves all workspaces the user has access to.
+func fetchWorkspaces() ([]Workspace, error) {
+	client := NewClient()
+	resp, err := client.DoRequest("GET", "/api/v1/workspaces", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var workspaces []Workspace
+	if err := ParseResponse(resp, &workspaces); err != nil {
+		return nil, err
+	}
+
+	return workspaces, nil
+}
+
 func getWorkspace(id int) error {
 	client := NewClient()
 	resp, err := client.DoRequest("GET", fmt.Sprintf("/api/v1/workspaces/%d", id), nil)
@@ -438,22 +471,10 @@ func deleteWorkspace(id int) error {
 
 // Workspace Users Implementation.
 func listWorkspaceUsers(workspaceID int) error {
-	client := NewClient()
-	resp, err := client.DoRequest("GET", fmt.Sprintf("/api/v1/workspaces/%d/users", workspaceID), nil)
+	users, err := fetchWorkspaceUsers(workspaceID)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		err := resp.Body.Close()
-		if err != nil {
-			fmt.Printf("Error closing response body: %v\n", err)
-		}
-	}()
-
-	var users []WorkspaceUser
-	if err := ParseResponse(resp, &users); err != nil {
-		return err
-	}
 
 	if len(users) == 0 {
 		fmt.Println("No users found in this workspace")
@@ -477,6 +498,26 @@ func listWorkspaceUsers(workspaceID int) error {
 	return nil
 }
 
+func fetchWorkspaceUsers(workspaceID int) ([]WorkspaceUser, error) {
+	client := NewClient()
+	resp, err := client.DoRequest("GET", fmt.Sprintf("/api/v1/workspaces/%d/users", workspaceID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var users []WorkspaceUser
+	if err := ParseResponse(resp, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 func addWorkspaceUser(workspaceID, userID int, role string, timeLimit int) error {
 	request := WorkspaceUserCreate{
 		UserID: userID,
@@ -614,6 +655,28 @@ func listWorkspaceBlueprints(workspaceID int) error {
 	return nil
 }
 
+// fetchWorkspaceBlueprints retrieves the blueprints shared with a workspace.
+func fetchWorkspaceBlueprints(workspaceID int) ([]WorkspaceBlueprint, error) {
+	client := NewClient()
+	resp, err := client.DoRequest("GET", fmt.Sprintf("/api/v1/workspaces/%d/blueprints", workspaceID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	var blueprints []WorkspaceBlueprint
+	if err := ParseResponse(resp, &blueprints); err != nil {
+		return nil, err
+	}
+
+	return blueprints, nil
+}
+
 func addWorkspaceBlueprint(workspaceID, blueprintID int, blueprintType, permission string) error {
 	request := WorkspaceBlueprint{
 		BlueprintID:   blueprintID,
@@ -680,7 +743,7 @@ func init() {
 	createWorkspaceCmd.Flags().Int("time-limit", 0, "Default time limit for users in the workspace (in seconds)")
 
 	// Workspace user command flags
-	addWorkspaceUserCmd.Flags().Int("user-id", 0, "ID of the user to add")
+	addWorkspaceUserCmd.Flags().String("user-id", "", "ID, name, or email of the user to add")
 	addWorkspaceUserCmd.Flags().String("role", "", "Role for the user (owner, manager, or member)")
 	addWorkspaceUserCmd.Flags().Int("time-limit", 0, "Time limit for the user in the workspace (in seconds)")
 
@@ -688,12 +751,28 @@ func init() {
 	updateWorkspaceUserCmd.Flags().Int("time-limit", 0, "New time limit for the user in the workspace (in seconds)")
 
 	// Workspace blueprint command flags
-	addWorkspaceBlueprintCmd.Flags().Int("blueprint-id", 0, "ID of the blueprint to share")
+	addWorkspaceBlueprintCmd.Flags().String("blueprint-id", "", "ID or name of the blueprint to share")
 	addWorkspaceBlueprintCmd.Flags().String("blueprint-type", "", "Type of the blueprint (range, vpc, subnet, or host)")
 	addWorkspaceBlueprintCmd.Flags().String("permission", "", "Permission level (view, deploy, or edit)")
 
 	removeWorkspaceBlueprintCmd.Flags().String("blueprint-type", "", "Type of the blueprint (range, vpc, subnet, or host)")
 
+	// Shell completion
+	for _, c := range []*cobra.Command{getWorkspaceCmd, deleteWorkspaceCmd, listWorkspaceUsersCmd,
+		addWorkspaceUserCmd, listWorkspaceBlueprintsCmd, addWorkspaceBlueprintCmd} {
+		c.ValidArgsFunction = completeWorkspaceArg
+	}
+	updateWorkspaceUserCmd.ValidArgsFunction = completeWorkspaceUserArgs
+	removeWorkspaceUserCmd.ValidArgsFunction = completeWorkspaceUserArgs
+	removeWorkspaceBlueprintCmd.ValidArgsFunction = completeWorkspaceBlueprintArgs
+
+	_ = addWorkspaceUserCmd.RegisterFlagCompletionFunc("role", completeValues(workspaceRoles...))
+	_ = updateWorkspaceUserCmd.RegisterFlagCompletionFunc("role", completeValues(workspaceRoles...))
+	_ = addWorkspaceBlueprintCmd.RegisterFlagCompletionFunc("blueprint-id", completeBlueprintTypeFlag)
+	_ = addWorkspaceBlueprintCmd.RegisterFlagCompletionFunc("blueprint-type", completeValues(blueprintTypes...))
+	_ = addWorkspaceBlueprintCmd.RegisterFlagCompletionFunc("permission", completeValues(blueprintPermissions...))
+	_ = removeWorkspaceBlueprintCmd.RegisterFlagCompletionFunc("blueprint-type", completeValues(blueprintTypes...))
+
 	// Add workspace subcommands
 	workspacesCmd.AddCommand(listWorkspacesCmd)
 	workspacesCmd.AddCommand(getWorkspaceCmd)
@@ -4,6 +4,7 @@ go 1.24
 
 require (
 	github.com/olekukonko/tablewriter v0.0.5
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
 	github.com/spf13/cobra v1.8.1
 	golang.org/x/term v0.30.0
 )